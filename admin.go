@@ -0,0 +1,49 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// AdminService provides access to site administration of
+// user accounts. It requires the authenticated user to be a
+// site administrator.
+type AdminService interface {
+	// ListUsers returns the list of all user accounts.
+	ListUsers(ctx context.Context, opts ListOptions) ([]*User, *Response, error)
+
+	// CreateUser creates a new user account.
+	CreateUser(ctx context.Context, in *structs.CreateUserOption) (*User, *Response, error)
+
+	// UpdateUser updates a user account. The login name and
+	// authentication source are required by the server.
+	UpdateUser(ctx context.Context, login string, in *structs.EditUserOption) (*User, *Response, error)
+
+	// SuspendUser prohibits a user account from signing in.
+	// The authentication source and login name of the account
+	// are retained.
+	SuspendUser(ctx context.Context, login string) (*User, *Response, error)
+
+	// UnsuspendUser allows a suspended user account to sign
+	// in again.
+	UnsuspendUser(ctx context.Context, login string) (*User, *Response, error)
+
+	// RenameUser changes the username of a user account.
+	RenameUser(ctx context.Context, login, name string) (*Response, error)
+
+	// DeleteUser deletes a user account. If purge is true,
+	// the repositories, organizations and other resources
+	// owned by the user are deleted as well.
+	DeleteUser(ctx context.Context, login string, purge bool) (*Response, error)
+
+	// CreateUserRepo creates a repository owned by the user.
+	CreateUserRepo(ctx context.Context, login string, in *structs.CreateRepoOption) (*Repository, *Response, error)
+
+	// CreateUserOrg creates an organization owned by the user.
+	CreateUserOrg(ctx context.Context, login string, in *structs.CreateOrgOption) (*structs.Organization, *Response, error)
+}
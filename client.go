@@ -67,6 +67,14 @@ type (
 		Last    int
 		First   int
 		Prev    int
+
+		// Total is the total number of items, if reported by
+		// the server.
+		Total int
+
+		// Truncated is true if the server reported that the
+		// list was truncated and more items are available.
+		Truncated bool
 	}
 
 	// Rate represents the rate limit for the current
@@ -99,17 +107,24 @@ type (
 		// Services used for communicating with the API.
 		Driver        Driver
 		Linker        Linker
+		Admin         AdminService
 		Contents      ContentService
 		Git           GitService
 		Organizations OrganizationService
 		Issues        IssueService
+		LFS           LFSService
 		Milestones    MilestoneService
+		Notifications NotificationService
+		Packages      PackageService
 		PullRequests  PullRequestService
 		Repositories  RepositoryService
 		Releases      ReleaseService
 		Reviews       ReviewService
+		Teams         TeamService
+		Times         TimeService
 		Users         UserService
 		Webhooks      WebhookService
+		Wiki          WikiService
 
 		// DumpResponse optionally specifies a function to
 		// dump the the response body for debugging purposes.
@@ -165,6 +180,12 @@ func (c *Client) Do(ctx context.Context, in *Request) (*Response, error) {
 		req.Header = in.Header
 	}
 
+	// the content length of a streamed body is unknown, unless
+	// provided with the Content-Length header.
+	if req.Body != nil && req.ContentLength == 0 {
+		req.ContentLength, _ = strconv.ParseInt(req.Header.Get("Content-Length"), 10, 64)
+	}
+
 	if in.Cookie != nil {
 		req.AddCookie(in.Cookie)
 	}
@@ -194,9 +215,18 @@ func newResponse(r *http.Response) *Response {
 		Body:   r.Body,
 	}
 	res.populatePageValues()
+	res.populateCountValues()
 	return res
 }
 
+// populateCountValues parses the HTTP item count response
+// headers and populates the total and truncation values in
+// the Response.
+func (r *Response) populateCountValues() {
+	r.Page.Total, _ = strconv.Atoi(r.Header.Get("X-Total-Count"))
+	r.Page.Truncated, _ = strconv.ParseBool(r.Header.Get("X-HasMore"))
+}
+
 // populatePageValues parses the HTTP Link response headers
 // and populates the various pagination link values in the
 // Response.
@@ -222,6 +252,14 @@ func (r *Response) populatePageValues() {
 			continue
 		}
 
+		// the next url is retained for cursor continuation,
+		// even if the link does not provide a page number.
+		for _, segment := range segments[1:] {
+			if strings.TrimSpace(segment) == `rel="next"` {
+				r.Page.NextURL = url.String()
+			}
+		}
+
 		page := url.Query().Get("page")
 		if page == "" {
 			continue
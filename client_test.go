@@ -39,4 +39,38 @@ func TestResponse(t *testing.T) {
 	if got, want := res.Page.Next, 4; got != want {
 		t.Errorf("Want rel next %d, got %d", want, got)
 	}
+	if got, want := res.Page.NextURL, "https://api.github.com/resource?page=4"; got != want {
+		t.Errorf("Want rel next url %s, got %s", want, got)
+	}
+}
+
+func TestResponse_Cursor(t *testing.T) {
+	res := newResponse(&http.Response{
+		StatusCode: 200,
+		Header: http.Header{
+			"Link": {`<https://api.github.com/resource?cursor=abc>; rel="next"`},
+		},
+	})
+	if got, want := res.Page.Next, 0; got != want {
+		t.Errorf("Want rel next %d, got %d", want, got)
+	}
+	if got, want := res.Page.NextURL, "https://api.github.com/resource?cursor=abc"; got != want {
+		t.Errorf("Want rel next url %s, got %s", want, got)
+	}
+}
+
+func TestResponse_Count(t *testing.T) {
+	res := newResponse(&http.Response{
+		StatusCode: 200,
+		Header: http.Header{
+			"X-Total-Count": {"120"},
+			"X-Hasmore":     {"true"},
+		},
+	})
+	if got, want := res.Page.Total, 120; got != want {
+		t.Errorf("Want total %d, got %d", want, got)
+	}
+	if got, want := res.Page.Truncated, true; got != want {
+		t.Errorf("Want truncated %v, got %v", want, got)
+	}
 }
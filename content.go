@@ -5,7 +5,10 @@
 
 package api
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type (
 	// Content stores the contents of a repository file.
@@ -41,6 +44,12 @@ type (
 		// Find returns the repository file content by path.
 		Find(ctx context.Context, repo, path, ref string) (*Content, *Response, error)
 
+		// Open returns a reader streaming the repository file
+		// content by path, starting at offset. If length is
+		// positive, at most length bytes are read. The caller
+		// is responsible for closing the returned reader.
+		Open(ctx context.Context, repo, path, ref string, offset, length int64) (io.ReadCloser, *Response, error)
+
 		// Create creates a new repositroy file.
 		Create(ctx context.Context, repo, path string, params *ContentParams) (*Response, error)
 
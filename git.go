@@ -7,7 +7,10 @@ package api
 
 import (
 	"context"
+	"io"
 	"time"
+
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 // EmptyCommit is an empty commit sha.
@@ -29,20 +32,66 @@ type (
 
 	// Commit represents a repository commit.
 	Commit struct {
-		Sha       string
-		Message   string
-		Author    Signature
-		Committer Signature
-		Link      string
+		Sha          string
+		Message      string
+		Author       Signature
+		Committer    Signature
+		Link         string
+		Verification *Verification
+
+		// Parents are the shas of the parent commits.
+		Parents []string
+
+		// Stats and Files are the change statistics and the
+		// files changed by the commit. They are only populated
+		// when requested with the Stats and Files options.
+		Stats *structs.CommitStats
+		Files []*structs.CommitAffectedFiles
+
+		// Note is the git note attached to the commit. It is
+		// only populated when requested with the Notes option.
+		Note string
+	}
+
+	// Verification represents the signature verification
+	// of a commit.
+	Verification struct {
+		Signed    bool
+		Verified  bool
+		Reason    string
+		Signature string
+		Signer    Signature
 	}
 
 	// CommitListOptions provides options for querying a
 	// list of repository commits.
 	CommitListOptions struct {
+		URL  string
 		Ref  string
 		Page int
 		Size int
 		Path string
+
+		// Author limits the list to commits by an author,
+		// matched by name or email.
+		Author string
+
+		// Since and Until limit the list to commits in a time
+		// window.
+		Since time.Time
+		Until time.Time
+
+		// Stats includes the change statistics of each commit.
+		Stats bool
+
+		// Files includes the files changed by each commit.
+		Files bool
+
+		// Notes includes the git note of each commit in the
+		// list. The server does not return notes with commits,
+		// so the notes of the listed commits are requested
+		// concurrently.
+		Notes bool
 	}
 
 	// Signature identifies a git commit creator.
@@ -68,18 +117,47 @@ type (
 		// FindCommit finds a git commit by ref.
 		FindCommit(ctx context.Context, repo, ref string) (*Commit, *Response, error)
 
+		// FindNote finds the git note attached to a commit.
+		FindNote(ctx context.Context, repo, sha string) (*structs.Note, *Response, error)
+
+		// FindRef finds a git reference by name, such as
+		// refs/heads/main or heads/main. If no reference has the
+		// exact name, the references nested below the name are
+		// returned, such as refs/heads/feature/x for
+		// refs/heads/feature. An exact match is returned alone.
+		FindRef(ctx context.Context, repo, ref string) ([]*structs.Reference, *Response, error)
+
 		// FindTag finds a git tag by name.
 		FindTag(ctx context.Context, repo, name string) (*Reference, *Response, error)
 
 		// ListBranches returns a list of git branches.
 		ListBranches(ctx context.Context, repo string, opts ListOptions) ([]*Reference, *Response, error)
 
-		// ListCommits returns a list of git commits.
+		// Diff returns the raw diff of a git commit. The caller
+		// is responsible for closing the returned reader.
+		Diff(ctx context.Context, repo, sha string) (io.ReadCloser, *Response, error)
+
+		// Patch returns a git commit formatted as an email
+		// patch. The caller is responsible for closing the
+		// returned reader.
+		Patch(ctx context.Context, repo, sha string) (io.ReadCloser, *Response, error)
+
+		// ListCommits returns a list of git commits. If the
+		// server truncated the list, Response.Page.Truncated
+		// is true.
 		ListCommits(ctx context.Context, repo string, opts CommitListOptions) ([]*Commit, *Response, error)
 
 		// ListChanges returns the changeset of a commit.
 		ListChanges(ctx context.Context, repo, ref string, opts ListOptions) ([]*Change, *Response, error)
 
+		// ListRefs returns the git references matching the
+		// pattern. A pattern without glob metacharacters matches
+		// the references it is a prefix of, such as refs/heads/,
+		// and an empty pattern matches every reference. Other
+		// patterns are matched with MatchRef. The refs/ prefix
+		// of the pattern may be omitted.
+		ListRefs(ctx context.Context, repo, pattern string) ([]*structs.Reference, *Response, error)
+
 		// ListTags returns a list of git tags.
 		ListTags(ctx context.Context, repo string, opts ListOptions) ([]*Reference, *Response, error)
 
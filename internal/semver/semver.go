@@ -0,0 +1,292 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package semver implements parsing, ordering and range
+// matching of semantic versions.
+package semver
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned when a version cannot be
+// parsed as a semantic version.
+var ErrInvalidVersion = errors.New("invalid semantic version")
+
+// ErrInvalidConstraint is returned when a version range
+// cannot be parsed.
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+// Version represents a semantic version.
+type Version struct {
+	Major, Minor, Patch int64
+	Pre                 []string
+
+	// parts is the number of numeric parts present in the
+	// parsed string, used to expand partial versions in
+	// constraints.
+	parts int
+}
+
+// Parse parses a semantic version. A leading v is allowed and
+// build metadata is ignored.
+func Parse(s string) (*Version, error) {
+	v, err := parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if v.parts != 3 {
+		return nil, ErrInvalidVersion
+	}
+	return v, nil
+}
+
+// parse parses a version that may omit the minor and patch
+// numbers.
+func parse(s string) (*Version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i != -1 {
+		s = s[:i]
+	}
+	v := new(Version)
+	if i := strings.IndexByte(s, '-'); i != -1 {
+		v.Pre = strings.Split(s[i+1:], ".")
+		for _, id := range v.Pre {
+			if id == "" {
+				return nil, ErrInvalidVersion
+			}
+		}
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return nil, ErrInvalidVersion
+	}
+	nums := []*int64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return nil, ErrInvalidVersion
+		}
+		*nums[i] = n
+	}
+	v.parts = len(parts)
+	return v, nil
+}
+
+// String returns the version string, without a leading v.
+func (v *Version) String() string {
+	s := strconv.FormatInt(v.Major, 10) + "." +
+		strconv.FormatInt(v.Minor, 10) + "." +
+		strconv.FormatInt(v.Patch, 10)
+	if len(v.Pre) != 0 {
+		s += "-" + strings.Join(v.Pre, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if the version has lower, equal
+// or higher precedence than o.
+func (v *Version) Compare(o *Version) int {
+	if c := compareInt(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	// a version without pre-release identifiers has higher
+	// precedence than a pre-release of the same version.
+	switch {
+	case len(v.Pre) == 0 && len(o.Pre) == 0:
+		return 0
+	case len(v.Pre) == 0:
+		return 1
+	case len(o.Pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Pre) && i < len(o.Pre); i++ {
+		if c := comparePre(v.Pre[i], o.Pre[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(int64(len(v.Pre)), int64(len(o.Pre)))
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePre compares pre-release identifiers. Numeric
+// identifiers are compared numerically and have lower
+// precedence than alphanumeric identifiers.
+func comparePre(a, b string) int {
+	x, errx := strconv.ParseInt(a, 10, 64)
+	y, erry := strconv.ParseInt(b, 10, 64)
+	switch {
+	case errx == nil && erry == nil:
+		return compareInt(x, y)
+	case errx == nil:
+		return -1
+	case erry == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Constraint represents a version range. Comparators
+// separated by spaces must all match, and sets of comparators
+// separated by || are alternatives.
+//
+// The comparison operators =, !=, >, >=, < and <= are
+// supported, as well as the ~ operator that allows patch
+// changes and the ^ operator that allows changes that do not
+// modify the left-most non-zero number.
+type Constraint struct {
+	sets [][]*comparator
+}
+
+type comparator struct {
+	op      string
+	version *Version
+}
+
+// ParseConstraint parses a version range such as
+// ">=1.2.0 <2.0.0 || ^3.1".
+func ParseConstraint(s string) (*Constraint, error) {
+	c := new(Constraint)
+	for _, alt := range strings.Split(s, "||") {
+		set := []*comparator{}
+		fields := strings.Fields(alt)
+		for i := 0; i < len(fields); i++ {
+			// an operator may be separated from its version by
+			// whitespace, such as ">= 1.0.0".
+			field := fields[i]
+			if isOperator(field) && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+			cmps, err := parseComparator(field)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, cmps...)
+		}
+		if len(set) == 0 {
+			return nil, ErrInvalidConstraint
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+// operators are the comparator operators, longest first.
+var operators = []string{">=", "<=", "!=", ">", "<", "=", "~", "^"}
+
+func isOperator(s string) bool {
+	for _, op := range operators {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+// upperBound returns the exclusive upper bound of a range,
+// with the lowest pre-release identifier so that pre-releases
+// of the bound, such as 2.0.0-rc.1 for 2.0.0, are excluded.
+func upperBound(major, minor, patch int64) *Version {
+	return &Version{Major: major, Minor: minor, Patch: patch, Pre: []string{"0"}}
+}
+
+// parseComparator parses a single comparator, expanding the
+// ~ and ^ operators to a lower and upper bound.
+func parseComparator(s string) ([]*comparator, error) {
+	op := ""
+	for _, prefix := range operators {
+		if strings.HasPrefix(s, prefix) {
+			op = prefix
+			break
+		}
+	}
+	v, err := parse(s[len(op):])
+	if err != nil {
+		return nil, ErrInvalidConstraint
+	}
+	switch op {
+	case "~":
+		upper := upperBound(v.Major, v.Minor+1, 0)
+		if v.parts == 1 {
+			upper = upperBound(v.Major+1, 0, 0)
+		}
+		return []*comparator{{">=", v}, {"<", upper}}, nil
+	case "^":
+		upper := upperBound(v.Major+1, 0, 0)
+		switch {
+		case v.Major != 0 || v.parts == 1:
+		case v.Minor != 0 || v.parts == 2:
+			upper = upperBound(0, v.Minor+1, 0)
+		default:
+			upper = upperBound(0, 0, v.Patch+1)
+		}
+		return []*comparator{{">=", v}, {"<", upper}}, nil
+	case "", "=":
+		// a partial version matches every version it is a
+		// prefix of.
+		switch v.parts {
+		case 1:
+			return []*comparator{{">=", v}, {"<", upperBound(v.Major+1, 0, 0)}}, nil
+		case 2:
+			return []*comparator{{">=", v}, {"<", upperBound(v.Major, v.Minor+1, 0)}}, nil
+		}
+		return []*comparator{{"=", v}}, nil
+	}
+	return []*comparator{{op, v}}, nil
+}
+
+// Check reports whether the version is in the range.
+func (c *Constraint) Check(v *Version) bool {
+	for _, set := range c.sets {
+		ok := true
+		for _, cmp := range set {
+			if !cmp.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *comparator) check(v *Version) bool {
+	n := v.Compare(c.version)
+	switch c.op {
+	case "=":
+		return n == 0
+	case "!=":
+		return n != 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	}
+	return false
+}
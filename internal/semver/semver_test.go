@@ -0,0 +1,127 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{in: "1.2.3", want: "1.2.3"},
+		{in: "v1.2.3", want: "1.2.3"},
+		{in: "1.2.3-rc.1+build.5", want: "1.2.3-rc.1"},
+		{in: "1.2", err: true},
+		{in: "1.2.x", err: true},
+		{in: "1.2.3-", err: true},
+		{in: "latest", err: true},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.in)
+		if test.err {
+			if err == nil {
+				t.Errorf("Want error parsing %q", test.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %s", test.in, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("Want version %s, got %s", test.want, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// versions in ascending order of precedence.
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := 0; i < len(versions)-1; i++ {
+		a, _ := Parse(versions[i])
+		b, _ := Parse(versions[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("Want %s lower than %s", a, b)
+		}
+		if a.Compare(a) != 0 {
+			t.Errorf("Want %s equal to itself", a)
+		}
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">=1.2.0 <2.0.0", "1.2.0", true},
+		{">=1.2.0 <2.0.0", "1.9.9", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{">=1.2.0 <2.0.0", "1.1.9", false},
+		{"^1.2", "1.5.0", true},
+		{"^1.2", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"1.2", "1.2.7", true},
+		{"1.2", "1.3.0", false},
+		{"=1.2.3", "1.2.3", true},
+		{"!=1.2.3", "1.2.3", false},
+		{"<1.0.0 || >=3.0.0", "0.9.0", true},
+		{"<1.0.0 || >=3.0.0", "2.0.0", false},
+		{"<1.0.0 || >=3.0.0", "3.1.0", true},
+		// pre-releases of the upper bound are excluded.
+		{"^1.2", "2.0.0-rc.1", false},
+		{"^1.2", "2.0.0-0", false},
+		{"^1.2", "1.9.9-rc.1", true},
+		{"^0.2.3", "0.3.0-beta", false},
+		{"~1.2.3", "1.3.0-alpha.1", false},
+		{"~1", "2.0.0-rc.1", false},
+		{"1.2", "1.3.0-rc.1", false},
+		// operators separated from the version by whitespace.
+		{">= 1.0.0", "1.0.0", true},
+		{">= 1.0.0 < 2.0.0", "1.5.0", true},
+		{">= 1.0.0 < 2.0.0", "2.0.0", false},
+		{"^ 1.2 || = 3.0.0", "3.0.0", true},
+		{"!= 1.2.3", "1.2.3", false},
+	}
+	for _, test := range tests {
+		c, err := ParseConstraint(test.constraint)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %s", test.constraint, err)
+			continue
+		}
+		v, _ := Parse(test.version)
+		if got := c.Check(v); got != test.want {
+			t.Errorf("Want %q check %s to be %v", test.constraint, test.version, test.want)
+		}
+	}
+}
+
+func TestConstraint_Invalid(t *testing.T) {
+	for _, s := range []string{"", ">=", "^x", "1.2 ||", ">= ", ">= >= 1.0.0", "1.0.0 <"} {
+		if _, err := ParseConstraint(s); err == nil {
+			t.Errorf("Want error parsing constraint %q", s)
+		}
+	}
+}
@@ -7,7 +7,26 @@ package api
 
 import (
 	"context"
+	"io"
 	"time"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// Issue type values for filtering issue lists.
+const (
+	IssueTypeIssue = "issues"
+	IssueTypePull  = "pulls"
+)
+
+// Sort values for ordering issue and pull request lists.
+const (
+	SortOldest       = "oldest"
+	SortRecentUpdate = "recentupdate"
+	SortLeastUpdate  = "leastupdate"
+	SortMostComment  = "mostcomment"
+	SortLeastComment = "leastcomment"
+	SortPriority     = "priority"
 )
 
 type (
@@ -22,6 +41,7 @@ type (
 		Locked      bool
 		Author      User
 		PullRequest PullRequest
+		Reactions   Reactions
 		Created     time.Time
 		Updated     time.Time
 	}
@@ -33,24 +53,73 @@ type (
 		Body  string
 	}
 
+	// IssueUpdateInput provides the input fields for updating
+	// an issue. Nil fields are left unchanged, and an empty
+	// body clears the issue body.
+	IssueUpdateInput struct {
+		Title *string
+		Body  *string
+	}
+
 	// IssueListOptions provides options for querying a
 	// list of repository issues.
 	IssueListOptions struct {
-		Page   int
-		Size   int
-		Open   bool
-		Closed bool
+		URL        string
+		Page       int
+		Size       int
+		Open       bool
+		Closed     bool
+		Labels     []string
+		Milestones []string
+		Assignee   string
+		Author     string
+		Mentioned  string
+		Since      time.Time
+		Before     time.Time
+		Type       string
+		Keyword    string
+		Sort       string
+	}
+
+	// IssueSearchOptions provides options for searching
+	// issues across the repositories of the authenticated
+	// user. The Assigned, Created, Mentioned and
+	// ReviewRequested flags limit the results to issues
+	// related to the authenticated user.
+	IssueSearchOptions struct {
+		URL             string
+		Page            int
+		Size            int
+		Open            bool
+		Closed          bool
+		Labels          []string
+		Milestones      []string
+		Since           time.Time
+		Before          time.Time
+		Type            string
+		Keyword         string
+		Owner           string
+		Team            string
+		Assigned        bool
+		Created         bool
+		Mentioned       bool
+		ReviewRequested bool
 	}
 
 	// Comment represents a comment.
 	Comment struct {
-		ID      int
-		Body    string
-		Author  User
-		Created time.Time
-		Updated time.Time
+		ID        int
+		Body      string
+		Author    User
+		Reactions Reactions
+		Created   time.Time
+		Updated   time.Time
 	}
 
+	// Reactions represents aggregated reaction counts keyed
+	// by reaction content (e.g. +1, -1, laugh, heart).
+	Reactions map[string]int
+
 	// CommentInput provides the input fields required for
 	// creating an issue comment.
 	CommentInput struct {
@@ -68,15 +137,30 @@ type (
 		// List returns the repository issue list.
 		List(context.Context, string, IssueListOptions) ([]*Issue, *Response, error)
 
+		// Search returns the issues matching the search options
+		// across the repositories of the authenticated user.
+		Search(context.Context, IssueSearchOptions) ([]*Issue, *Response, error)
+
 		// ListComments returns the issue comment list.
 		ListComments(context.Context, string, int, ListOptions) ([]*Comment, *Response, error)
 
+		// ListTimeline returns the issue timeline events,
+		// optionally limited to events updated since the
+		// given time.
+		ListTimeline(context.Context, string, int, time.Time, ListOptions) ([]*TimelineEvent, *Response, error)
+
 		// Create creates a new issue.
 		Create(context.Context, string, *IssueInput) (*Issue, *Response, error)
 
+		// Update updates the title and body of an issue.
+		Update(context.Context, string, int, *IssueUpdateInput) (*Issue, *Response, error)
+
 		// CreateComment creates a new issue comment.
 		CreateComment(context.Context, string, int, *CommentInput) (*Comment, *Response, error)
 
+		// UpdateComment updates an issue comment.
+		UpdateComment(context.Context, string, int, int, *CommentInput) (*Comment, *Response, error)
+
 		// DeleteComment deletes an issue comment.
 		DeleteComment(context.Context, string, int, int) (*Response, error)
 
@@ -88,5 +172,73 @@ type (
 
 		// Unlock unlocks an issue discussion.
 		Unlock(context.Context, string, int) (*Response, error)
+
+		// ListReactions returns the issue reaction list.
+		ListReactions(context.Context, string, int, ListOptions) ([]*structs.Reaction, *Response, error)
+
+		// AddReaction adds a reaction to an issue.
+		AddReaction(context.Context, string, int, string) (*structs.Reaction, *Response, error)
+
+		// RemoveReaction removes a reaction from an issue.
+		RemoveReaction(context.Context, string, int, string) (*Response, error)
+
+		// ListCommentReactions returns the issue comment reaction list.
+		ListCommentReactions(context.Context, string, int, ListOptions) ([]*structs.Reaction, *Response, error)
+
+		// AddCommentReaction adds a reaction to an issue comment.
+		AddCommentReaction(context.Context, string, int, string) (*structs.Reaction, *Response, error)
+
+		// RemoveCommentReaction removes a reaction from an issue comment.
+		RemoveCommentReaction(context.Context, string, int, string) (*Response, error)
+
+		// ListAttachments returns the issue attachment list.
+		ListAttachments(context.Context, string, int, ListOptions) ([]*structs.Attachment, *Response, error)
+
+		// FindAttachment returns an issue attachment by id.
+		FindAttachment(context.Context, string, int, int64) (*structs.Attachment, *Response, error)
+
+		// UploadAttachment uploads an issue attachment with the given
+		// file name, streaming the content from the reader.
+		UploadAttachment(context.Context, string, int, string, io.Reader) (*structs.Attachment, *Response, error)
+
+		// UpdateAttachment updates an issue attachment.
+		UpdateAttachment(context.Context, string, int, int64, *structs.EditAttachmentOptions) (*structs.Attachment, *Response, error)
+
+		// DeleteAttachment deletes an issue attachment.
+		DeleteAttachment(context.Context, string, int, int64) (*Response, error)
+
+		// DownloadAttachment returns the content of an issue
+		// attachment. The caller is responsible for closing the
+		// returned reader.
+		DownloadAttachment(context.Context, string, int, int64) (io.ReadCloser, *Response, error)
+
+		// ListCommentAttachments returns the issue comment attachment list.
+		ListCommentAttachments(context.Context, string, int, ListOptions) ([]*structs.Attachment, *Response, error)
+
+		// FindCommentAttachment returns an issue comment attachment by id.
+		FindCommentAttachment(context.Context, string, int, int64) (*structs.Attachment, *Response, error)
+
+		// UploadCommentAttachment uploads an issue comment attachment
+		// with the given file name, streaming the content from the reader.
+		UploadCommentAttachment(context.Context, string, int, string, io.Reader) (*structs.Attachment, *Response, error)
+
+		// UpdateCommentAttachment updates an issue comment attachment.
+		UpdateCommentAttachment(context.Context, string, int, int64, *structs.EditAttachmentOptions) (*structs.Attachment, *Response, error)
+
+		// DeleteCommentAttachment deletes an issue comment attachment.
+		DeleteCommentAttachment(context.Context, string, int, int64) (*Response, error)
+
+		// DownloadCommentAttachment returns the content of an issue
+		// comment attachment. The caller is responsible for closing
+		// the returned reader.
+		DownloadCommentAttachment(context.Context, string, int, int64) (io.ReadCloser, *Response, error)
 	}
 )
+
+// Total returns the total number of reactions.
+func (r Reactions) Total() (n int) {
+	for _, v := range r {
+		n += v
+	}
+	return
+}
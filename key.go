@@ -0,0 +1,74 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidKey is returned when a public key cannot be
+// parsed in the authorized_keys format.
+var ErrInvalidKey = errors.New("invalid public key")
+
+// ErrDuplicateKey is returned when a public key with the
+// same fingerprint already exists.
+var ErrDuplicateKey = errors.New("duplicate public key")
+
+// supported ssh public key types.
+var keyTypes = map[string]struct{}{
+	"ssh-rsa":                            {},
+	"ssh-dss":                            {},
+	"ssh-ed25519":                        {},
+	"ecdsa-sha2-nistp256":                {},
+	"ecdsa-sha2-nistp384":                {},
+	"ecdsa-sha2-nistp521":                {},
+	"sk-ecdsa-sha2-nistp256@openssh.com": {},
+	"sk-ssh-ed25519@openssh.com":         {},
+}
+
+// PublicKeyInfo provides the locally computed details of
+// an ssh public key.
+type PublicKeyInfo struct {
+	Type        string
+	Fingerprint string
+	Comment     string
+}
+
+// ParsePublicKey parses an ssh public key in the
+// authorized_keys format and returns its type and SHA256
+// fingerprint, matching the fingerprint reported by the
+// server for deploy keys and user keys.
+func ParsePublicKey(s string) (*PublicKeyInfo, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return nil, ErrInvalidKey
+	}
+	if _, ok := keyTypes[fields[0]]; !ok {
+		return nil, ErrInvalidKey
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	// the key blob must begin with the length-prefixed
+	// key type, which must match the declared type.
+	if len(blob) < 4 {
+		return nil, ErrInvalidKey
+	}
+	size := binary.BigEndian.Uint32(blob)
+	if uint64(len(blob)-4) < uint64(size) || string(blob[4:4+size]) != fields[0] {
+		return nil, ErrInvalidKey
+	}
+	sum := sha256.Sum256(blob)
+	return &PublicKeyInfo{
+		Type:        fields[0],
+		Fingerprint: "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]),
+		Comment:     strings.Join(fields[2:], " "),
+	}, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import "testing"
+
+func TestParsePublicKey(t *testing.T) {
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIce0Hdcrc3wYIwaEUl9qO7Ut0MDhG4BzQ23mhgPu69H ci@example.com"
+	got, err := ParsePublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ssh-ed25519"; got.Type != want {
+		t.Errorf("Want key type %q, got %q", want, got.Type)
+	}
+	if want := "SHA256:aPFB02PfxdmEHCbUG985H7nhaKwgBlKAG2J/zbUDDps"; got.Fingerprint != want {
+		t.Errorf("Want fingerprint %q, got %q", want, got.Fingerprint)
+	}
+	if want := "ci@example.com"; got.Comment != want {
+		t.Errorf("Want comment %q, got %q", want, got.Comment)
+	}
+}
+
+func TestParsePublicKey_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"ssh-ed25519",
+		"ssh-foo AAAAC3NzaC1lZDI1NTE5AAAAIIce0Hdcrc3wYIwaEUl9qO7Ut0MDhG4BzQ23mhgPu69H",
+		"ssh-ed25519 not-base64!",
+		// declared type does not match the key blob.
+		"ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAIIce0Hdcrc3wYIwaEUl9qO7Ut0MDhG4BzQ23mhgPu69H",
+	}
+	for _, test := range tests {
+		if _, err := ParsePublicKey(test); err != ErrInvalidKey {
+			t.Errorf("Want invalid key error for %q, got %v", test, err)
+		}
+	}
+}
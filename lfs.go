@@ -0,0 +1,94 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"io"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type (
+	// LFSLockListOptions provides options for querying a
+	// list of Git LFS locks.
+	LFSLockListOptions struct {
+		// Cursor continues the list from the next cursor of a
+		// previous response.
+		Cursor string
+		Size   int
+
+		// Path, ID and Refspec filter the locks by locked
+		// path, lock id and reference.
+		Path    string
+		ID      string
+		Refspec string
+	}
+
+	// LFSLockVerifyOptions provides options for verifying
+	// the Git LFS locks of a reference before pushing.
+	LFSLockVerifyOptions struct {
+		// Cursor continues the list from the next cursor of a
+		// previous response.
+		Cursor  string
+		Size    int
+		Refspec string
+	}
+
+	// LockConflictError is returned when a Git LFS lock cannot
+	// be created because the path is already locked. Lock is
+	// the existing lock.
+	LockConflictError struct {
+		Message string
+		Lock    *structs.LFSLock
+	}
+
+	// LFSService provides access to the Git LFS locking API of
+	// a repository. The server authenticates Git LFS requests
+	// with basic authentication, using an access token as the
+	// password.
+	LFSService interface {
+		// CreateLock locks a path. If the path is already
+		// locked a *LockConflictError is returned.
+		CreateLock(ctx context.Context, repo string, in *structs.LFSLockRequest) (*structs.LFSLock, *Response, error)
+
+		// ListLocks returns the locks of the repository.
+		ListLocks(ctx context.Context, repo string, opts LFSLockListOptions) (*structs.LFSLockList, *Response, error)
+
+		// VerifyLocks returns the locks of the repository split
+		// into the locks owned by the authenticated user, and
+		// the locks owned by other users.
+		VerifyLocks(ctx context.Context, repo string, opts LFSLockVerifyOptions) (*structs.LFSLockListVerify, *Response, error)
+
+		// Unlock deletes a lock by id. Locks owned by other
+		// users can only be deleted by force.
+		Unlock(ctx context.Context, repo, id string, force bool) (*structs.LFSLock, *Response, error)
+
+		// Batch requests the transfer actions of a set of
+		// objects.
+		Batch(ctx context.Context, repo string, in *structs.LFSBatchRequest) (*structs.LFSBatchResponse, *Response, error)
+
+		// Download opens the content of an object using its
+		// download action. If offset is not zero the content is
+		// requested from the offset with a range request, and
+		// the response status is 206 if the server honored the
+		// range. The caller is responsible for closing the
+		// returned reader.
+		Download(ctx context.Context, action *structs.LFSAction, offset int64) (io.ReadCloser, *Response, error)
+
+		// Upload streams the content of an object using its
+		// upload action.
+		Upload(ctx context.Context, action *structs.LFSAction, r io.Reader, size int64) (*Response, error)
+
+		// Verify confirms an upload using the verify action of
+		// the object.
+		Verify(ctx context.Context, action *structs.LFSAction, obj *structs.LFSPointer) (*Response, error)
+	}
+)
+
+// Error returns the error message.
+func (e *LockConflictError) Error() string {
+	return e.Message
+}
@@ -20,6 +20,7 @@ type (
 
 	// MilestoneListOptions provides options for querying a list of repository milestones.
 	MilestoneListOptions struct {
+		URL    string
 		Page   int
 		Size   int
 		Open   bool
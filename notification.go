@@ -0,0 +1,100 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// Notification thread status values.
+const (
+	NotificationUnread = "unread"
+	NotificationRead   = "read"
+	NotificationPinned = "pinned"
+)
+
+type (
+	// NotificationListOptions provides options for querying
+	// a list of notification threads.
+	NotificationListOptions struct {
+		URL  string
+		Page int
+		Size int
+
+		// All includes threads that have been read.
+		All bool
+
+		// Status filters the threads by status. The server
+		// defaults to unread and pinned threads.
+		Status []string
+
+		// SubjectTypes filters the threads by subject type.
+		SubjectTypes []structs.NotifySubjectType
+
+		Since  time.Time
+		Before time.Time
+
+		// ETag and LastModified hold the validators of a
+		// previous response. If set the request is made
+		// conditional, and an unchanged list is reported with
+		// a 304 response status and no threads.
+		ETag         string
+		LastModified string
+	}
+
+	// NotificationMarkOptions provides options for changing
+	// the status of notification threads in bulk.
+	NotificationMarkOptions struct {
+		// LastReadAt limits the change to threads updated
+		// before the given time. The server defaults to now.
+		LastReadAt time.Time
+
+		// All includes threads that have been read.
+		All bool
+
+		// Status selects the threads to change by status. The
+		// server defaults to unread threads.
+		Status []string
+
+		// ToStatus is the status the threads are changed to.
+		// The server defaults to read.
+		ToStatus string
+	}
+
+	// NotificationService provides access to notification
+	// threads of the authenticated user.
+	NotificationService interface {
+		// List returns the notification threads of the
+		// authenticated user.
+		List(ctx context.Context, opts NotificationListOptions) ([]*structs.NotificationThread, *Response, error)
+
+		// ListRepo returns the notification threads of the
+		// authenticated user for a repository.
+		ListRepo(ctx context.Context, repo string, opts NotificationListOptions) ([]*structs.NotificationThread, *Response, error)
+
+		// Find returns a notification thread by id.
+		Find(ctx context.Context, id int64) (*structs.NotificationThread, *Response, error)
+
+		// CountNew returns the number of unread notification
+		// threads of the authenticated user.
+		CountNew(ctx context.Context) (int64, *Response, error)
+
+		// Mark changes the status of a notification thread.
+		Mark(ctx context.Context, id int64, status string) (*structs.NotificationThread, *Response, error)
+
+		// MarkAll changes the status of the notification threads
+		// of the authenticated user and returns the changed
+		// threads.
+		MarkAll(ctx context.Context, opts NotificationMarkOptions) ([]*structs.NotificationThread, *Response, error)
+
+		// MarkRepo changes the status of the notification
+		// threads of the authenticated user for a repository
+		// and returns the changed threads.
+		MarkRepo(ctx context.Context, repo string, opts NotificationMarkOptions) ([]*structs.NotificationThread, *Response, error)
+	}
+)
@@ -7,6 +7,8 @@ package api
 
 import (
 	"context"
+
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type (
@@ -43,9 +45,32 @@ type (
 		// by a given user account.
 		FindMembership(ctx context.Context, name, username string) (*Membership, *Response, error)
 
+		// FindPermissions returns the organization permissions
+		// of a given user account.
+		FindPermissions(ctx context.Context, name, username string) (*structs.OrganizationPermissions, *Response, error)
+
 		// List returns the user organization list.
 		List(ctx context.Context, opts ListOptions) ([]*Organization, *Response, error)
 
+		// Create creates a new organization.
+		Create(ctx context.Context, in *structs.CreateOrgOption) (*structs.Organization, *Response, error)
+
+		// Update updates an organization.
+		Update(ctx context.Context, name string, in *structs.EditOrgOption) (*structs.Organization, *Response, error)
+
+		// Delete deletes an organization.
+		Delete(ctx context.Context, name string) (*Response, error)
+
+		// ListMembers returns the organization member list.
+		ListMembers(ctx context.Context, name string, opts ListOptions) ([]*User, *Response, error)
+
+		// ListPublicMembers returns the organization public
+		// member list.
+		ListPublicMembers(ctx context.Context, name string, opts ListOptions) ([]*User, *Response, error)
+
+		// RemoveMember removes a user from the organization.
+		RemoveMember(ctx context.Context, name, username string) (*Response, error)
+
 		CheckMember(context.Context, string, string) (bool, *Response, error)
 
 		FindTeamMember(context.Context, int64, string) (*Member, *Response, error)
@@ -0,0 +1,46 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type (
+	// PackageListOptions provides options for querying a
+	// list of packages.
+	PackageListOptions struct {
+		URL  string
+		Page int
+		Size int
+
+		// Type filters the packages by type, such as npm,
+		// maven or container.
+		Type string
+
+		// Query filters the packages by name.
+		Query string
+	}
+
+	// PackageService provides access to the package registry.
+	// Every package returned by the service is a single
+	// version of the named package.
+	PackageService interface {
+		// List returns the package versions of an owner.
+		List(ctx context.Context, owner string, opts PackageListOptions) ([]*structs.Package, *Response, error)
+
+		// Find returns a package version.
+		Find(ctx context.Context, owner, kind, name, version string) (*structs.Package, *Response, error)
+
+		// ListFiles returns the files of a package version,
+		// including their hashes.
+		ListFiles(ctx context.Context, owner, kind, name, version string) ([]*structs.PackageFile, *Response, error)
+
+		// Delete deletes a package version.
+		Delete(ctx context.Context, owner, kind, name, version string) (*Response, error)
+	}
+)
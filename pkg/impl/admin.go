@@ -0,0 +1,113 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type adminService struct {
+	client *wrapper
+}
+
+func (s *adminService) ListUsers(ctx context.Context, opts api.ListOptions) ([]*api.User, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/admin/users?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*user{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertUserList(out), res, err
+}
+
+func (s *adminService) CreateUser(ctx context.Context, in *structs.CreateUserOption) (*api.User, *api.Response, error) {
+	path := "api/v1/admin/users"
+	out := new(user)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertUser(out), res, err
+}
+
+func (s *adminService) UpdateUser(ctx context.Context, login string, in *structs.EditUserOption) (*api.User, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/admin/users/%s", login)
+	out := new(user)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertUser(out), res, err
+}
+
+func (s *adminService) SuspendUser(ctx context.Context, login string) (*api.User, *api.Response, error) {
+	return s.prohibitLogin(ctx, login, true)
+}
+
+func (s *adminService) UnsuspendUser(ctx context.Context, login string) (*api.User, *api.Response, error) {
+	return s.prohibitLogin(ctx, login, false)
+}
+
+func (s *adminService) RenameUser(ctx context.Context, login, name string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/admin/users/%s/rename", login)
+	in := &renameUserInput{NewName: name}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *adminService) DeleteUser(ctx context.Context, login string, purge bool) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/admin/users/%s", login)
+	if purge {
+		path = path + "?purge=true"
+	}
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *adminService) CreateUserRepo(ctx context.Context, login string, in *structs.CreateRepoOption) (*api.Repository, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/admin/users/%s/repos", login)
+	out := new(repository)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertRepository(out), res, err
+}
+
+func (s *adminService) CreateUserOrg(ctx context.Context, login string, in *structs.CreateOrgOption) (*structs.Organization, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/admin/users/%s/orgs", login)
+	out := new(structs.Organization)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+// prohibitLogin updates the prohibit login flag of a user
+// account. The server requires the authentication source and
+// login name, so the current values are fetched and sent back
+// unchanged.
+func (s *adminService) prohibitLogin(ctx context.Context, login string, prohibit bool) (*api.User, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/users/%s", login)
+	auth := new(userAuth)
+	res, err := s.client.do(ctx, "GET", path, nil, auth)
+	if err != nil {
+		return nil, res, err
+	}
+	// the login name of a local account may be empty, in
+	// which case it defaults to the username.
+	if auth.LoginName == "" {
+		auth.LoginName = login
+	}
+	in := &structs.EditUserOption{
+		SourceID:      auth.SourceID,
+		LoginName:     auth.LoginName,
+		ProhibitLogin: &prohibit,
+	}
+	return s.UpdateUser(ctx, login, in)
+}
+
+//
+// native data structures
+//
+
+// user authentication source, visible to site administrators.
+type userAuth struct {
+	SourceID  int64  `json:"source_id"`
+	LoginName string `json:"login_name"`
+}
+
+type renameUserInput struct {
+	NewName string `json:"new_username"`
+}
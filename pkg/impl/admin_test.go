@@ -0,0 +1,268 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestAdminListUsers(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/admin/users").
+		MatchParam("page", "1").
+		MatchParam("limit", "50").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/org_members.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Admin.ListUsers(context.Background(), api.ListOptions{Page: 1, Size: 50})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*api.User{}
+	raw, _ := ioutil.ReadFile("testdata/org_members.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestAdminCreateUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/admin/users").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"source_id":            0,
+			"login_name":           "",
+			"username":             "jcitizen",
+			"full_name":            "Jane Citizen",
+			"email":                "jane@example.com",
+			"password":             "correct-horse",
+			"must_change_password": true,
+			"send_notify":          false,
+			"restricted":           nil,
+			"visibility":           "",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/user.json")
+
+	mustChange := true
+	in := &structs.CreateUserOption{
+		Username:           "jcitizen",
+		FullName:           "Jane Citizen",
+		Email:              "jane@example.com",
+		Password:           "correct-horse",
+		MustChangePassword: &mustChange,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Admin.CreateUser(context.Background(), in)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.User)
+	raw, _ := ioutil.ReadFile("testdata/user.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestAdminSuspendUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/users/jcitizen").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/admin/users/jcitizen").
+		MatchType("json").
+		BodyString(`"source_id":0`).
+		BodyString(`"login_name":"jcitizen"`).
+		BodyString(`"prohibit_login":true`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Admin.SuspendUser(context.Background(), "jcitizen"); err != nil {
+		t.Error(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestAdminSuspendUser_ExternalSource(t *testing.T) {
+	defer gock.Off()
+
+	// the authentication source and login name of the
+	// account are sent back unchanged.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/users/jcitizen").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user_ldap.json")
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/admin/users/jcitizen").
+		MatchType("json").
+		BodyString(`"source_id":3`).
+		BodyString(`"login_name":"jane.citizen"`).
+		BodyString(`"prohibit_login":true`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/user_ldap.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Admin.SuspendUser(context.Background(), "jcitizen"); err != nil {
+		t.Error(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestAdminUnsuspendUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/users/jcitizen").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user_ldap.json")
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/admin/users/jcitizen").
+		MatchType("json").
+		BodyString(`"source_id":3`).
+		BodyString(`"login_name":"jane.citizen"`).
+		BodyString(`"prohibit_login":false`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/user_ldap.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Admin.UnsuspendUser(context.Background(), "jcitizen"); err != nil {
+		t.Error(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestAdminSuspendUser_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/users/jcitizen").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"user does not exist"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Admin.SuspendUser(context.Background(), "jcitizen"); err == nil {
+		t.Errorf("Expect error for a missing user")
+	}
+}
+
+func TestAdminRenameUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/admin/users/jcitizen/rename").
+		MatchType("json").
+		JSON(map[string]string{"new_username": "jane"}).
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Admin.RenameUser(context.Background(), "jcitizen", "jane"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdminDeleteUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/admin/users/jcitizen").
+		MatchParam("purge", "true").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Admin.DeleteUser(context.Background(), "jcitizen", true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdminCreateUserRepo(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/admin/users/jcitizen/repos").
+		Reply(201).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Admin.CreateUserRepo(context.Background(), "jcitizen", &structs.CreateRepoOption{Name: "hello-world"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.Repository)
+	raw, _ := ioutil.ReadFile("testdata/repo.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestAdminCreateUserOrg(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/admin/users/jcitizen/orgs").
+		Reply(201).
+		Type("application/json").
+		File("testdata/organization.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Admin.CreateUserOrg(context.Background(), "jcitizen", &structs.CreateOrgOption{UserName: "gogits"})
+	if err != nil {
+		t.Error(err)
+	}
+	if got.UserName != "gogits" {
+		t.Errorf("Want organization gogits, got %s", got.UserName)
+	}
+}
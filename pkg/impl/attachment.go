@@ -0,0 +1,106 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/internal/null"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// attachments provides access to the attachments of a
+// release, issue or issue comment at the given endpoint.
+type attachments struct {
+	client *wrapper
+	path   string
+}
+
+func (s attachments) list(ctx context.Context, opts api.ListOptions) ([]*structs.Attachment, *api.Response, error) {
+	path := s.path
+	if query := encodeListOptions(opts); query != "" {
+		path = path + "?" + query
+	}
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.Attachment{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s attachments) find(ctx context.Context, id int64) (*structs.Attachment, *api.Response, error) {
+	path := fmt.Sprintf("%s/%d", s.path, id)
+	out := new(structs.Attachment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s attachments) upload(ctx context.Context, name string, r io.Reader) (*structs.Attachment, *api.Response, error) {
+	path := fmt.Sprintf("%s?name=%s", s.path, url.QueryEscape(name))
+	out := new(structs.Attachment)
+	res, err := s.client.upload(ctx, path, "attachment", name, r, out)
+	return out, res, err
+}
+
+func (s attachments) update(ctx context.Context, id int64, in *structs.EditAttachmentOptions) (*structs.Attachment, *api.Response, error) {
+	path := fmt.Sprintf("%s/%d", s.path, id)
+	out := new(structs.Attachment)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return out, res, err
+}
+
+func (s attachments) delete(ctx context.Context, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("%s/%d", s.path, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s attachments) download(ctx context.Context, id int64) (io.ReadCloser, *api.Response, error) {
+	attachment, res, err := s.find(ctx, id)
+	if err != nil {
+		return nil, res, err
+	}
+	return s.client.stream(ctx, "GET", attachment.DownloadURL, nil)
+}
+
+//
+// native data structures
+//
+
+type Attachment struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Size          int64     `json:"size"`
+	DownloadCount int64     `json:"download_count"`
+	Created       null.Time `json:"created_at"`
+	UUID          string    `json:"uuid"`
+	DownloadURL   string    `json:"browser_download_url"`
+}
+
+//
+// native data structure conversion
+//
+
+func convertAttachmentList(src []*Attachment) []*structs.Attachment {
+	var dst []*structs.Attachment
+	for _, v := range src {
+		dst = append(dst, convertAttachment(v))
+	}
+	return dst
+}
+
+func convertAttachment(src *Attachment) *structs.Attachment {
+	return &structs.Attachment{
+		ID:            src.ID,
+		Name:          src.Name,
+		Size:          src.Size,
+		DownloadCount: src.DownloadCount,
+		Created:       src.Created.ValueOrZero(),
+		UUID:          src.UUID,
+		DownloadURL:   src.DownloadURL,
+	}
+}
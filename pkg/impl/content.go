@@ -9,6 +9,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 
 	api "github.com/gitbundle/api"
 )
@@ -27,6 +29,30 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*api
 	}, res, err
 }
 
+func (s *contentService) Open(ctx context.Context, repo, path, ref string, offset, length int64) (io.ReadCloser, *api.Response, error) {
+	endpoint := fmt.Sprintf("api/v1/repos/%s/raw/%s/%s", repo, api.TrimRef(ref), path)
+	if offset <= 0 && length <= 0 {
+		return s.client.stream(ctx, "GET", endpoint, nil)
+	}
+	header := map[string][]string{
+		"Range": {encodeRange(offset, length)},
+	}
+	body, res, err := s.client.stream(ctx, "GET", endpoint, header)
+	if err != nil || res.Status == 206 {
+		return body, res, err
+	}
+	// the server ignored the range and returned the full
+	// content, so the range is applied to the response body.
+	if _, err := io.CopyN(ioutil.Discard, body, offset); err != nil && err != io.EOF {
+		body.Close()
+		return nil, res, err
+	}
+	if length > 0 {
+		body = &limitedReadCloser{io.LimitReader(body, length), body}
+	}
+	return body, res, nil
+}
+
 func (s *contentService) Create(ctx context.Context, repo, path string, params *api.ContentParams) (*api.Response, error) {
 	return nil, api.ErrNotSupported
 }
@@ -46,6 +72,13 @@ func (s *contentService) List(ctx context.Context, repo, path, ref string, _ api
 	return convertContentInfoList(out), res, err
 }
 
+// limitedReadCloser limits the bytes read from an underlying
+// response body, and closes the body.
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
 type content struct {
 	Path string `json:"path"`
 	Type string `json:"type"`
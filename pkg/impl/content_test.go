@@ -44,6 +44,83 @@ func TestContentFind(t *testing.T) {
 	}
 }
 
+func TestContentOpen(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/raw/main/README.md").
+		Reply(200).
+		Type("plain/text").
+		BodyString("Hello World\n")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.Contents.Open(context.Background(), "go-magit/magit", "README.md", "main", 0, 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, _ := ioutil.ReadAll(rc)
+	if got, want := string(data), "Hello World\n"; got != want {
+		t.Errorf("Want file Data %q, got %q", want, got)
+	}
+}
+
+func TestContentOpen_Range(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/raw/main/README.md").
+		MatchHeader("Range", "bytes=6-10").
+		Reply(206).
+		Type("plain/text").
+		SetHeader("Content-Range", "bytes 6-10/12").
+		BodyString("World")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, res, err := client.Contents.Open(context.Background(), "go-magit/magit", "README.md", "main", 6, 5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, _ := ioutil.ReadAll(rc)
+	if got, want := string(data), "World"; got != want {
+		t.Errorf("Want file Data %q, got %q", want, got)
+	}
+	if got, want := res.Status, 206; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+}
+
+func TestContentOpen_RangeIgnored(t *testing.T) {
+	defer gock.Off()
+
+	// the server ignores the range and returns the full
+	// content.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/raw/main/README.md").
+		MatchHeader("Range", "bytes=6-10").
+		Reply(200).
+		Type("plain/text").
+		BodyString("Hello World\n")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.Contents.Open(context.Background(), "go-magit/magit", "README.md", "main", 6, 5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, _ := ioutil.ReadAll(rc)
+	if got, want := string(data), "World"; got != want {
+		t.Errorf("Want file Data %q, got %q", want, got)
+	}
+}
+
 func TestContentCreate(t *testing.T) {
 	client, _ := New("https://example.gitbundle.com")
 	_, err := client.Contents.Create(context.Background(), "go-magit/magit", "README.md", nil)
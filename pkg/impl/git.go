@@ -7,11 +7,15 @@ package impl
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
+	"strings"
 	"time"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type gitService struct {
@@ -37,6 +41,39 @@ func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*api.Com
 	return convertCommitInfo(out), res, err
 }
 
+func (s *gitService) FindNote(ctx context.Context, repo, sha string) (*structs.Note, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/git/notes/%s", repo, sha)
+	out := new(structs.Note)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *gitService) FindRef(ctx context.Context, repo, ref string) ([]*structs.Reference, *api.Response, error) {
+	ref = api.ExpandRef(ref, "refs")
+	path := fmt.Sprintf("api/v1/repos/%s/git/%s", repo, ref)
+	out := refList{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	// the server matches references by prefix, so the list
+	// may contain the exact match, references nested below
+	// the name, and references that only share the prefix.
+	refs := []*structs.Reference{}
+	for _, v := range out {
+		switch {
+		case v.Ref == ref:
+			return []*structs.Reference{v}, res, nil
+		case strings.HasPrefix(v.Ref, ref+"/"):
+			refs = append(refs, v)
+		}
+	}
+	if len(refs) == 0 {
+		return nil, res, api.ErrNotFound
+	}
+	return refs, res, nil
+}
+
 func (s *gitService) FindTag(ctx context.Context, repo, name string) (*api.Reference, *api.Response, error) {
 	name = api.TrimRef(name)
 	path := fmt.Sprintf("api/v1/repos/%s/git/refs/tags/%s", repo, url.PathEscape(name))
@@ -53,18 +90,77 @@ func (s *gitService) FindTag(ctx context.Context, repo, name string) (*api.Refer
 	return nil, res, api.ErrNotFound
 }
 
+func (s *gitService) Diff(ctx context.Context, repo, sha string) (io.ReadCloser, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/git/commits/%s.diff", repo, sha)
+	return s.client.stream(ctx, "GET", path, nil)
+}
+
+func (s *gitService) Patch(ctx context.Context, repo, sha string) (io.ReadCloser, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/git/commits/%s.patch", repo, sha)
+	return s.client.stream(ctx, "GET", path, nil)
+}
+
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts api.ListOptions) ([]*api.Reference, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/branches?%s", repo, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*branch{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertBranchList(out), res, err
 }
 
-func (s *gitService) ListCommits(ctx context.Context, repo string, _ api.CommitListOptions) ([]*api.Commit, *api.Response, error) {
-	path := fmt.Sprintf("api/v1/repos/%s/commits", repo)
+func (s *gitService) ListCommits(ctx context.Context, repo string, opts api.CommitListOptions) ([]*api.Commit, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/commits?%s", repo, encodeCommitListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*commitInfo{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertCommitList(out), res, err
+	if err != nil || !opts.Notes {
+		return convertCommitList(out), res, err
+	}
+	commits := convertCommitList(out)
+	err = each(ctx, len(commits), func(ctx context.Context, i int) error {
+		note, res, err := s.FindNote(ctx, repo, commits[i].Sha)
+		// the commits exist, so a note that is not found
+		// means the commit has no note.
+		if res != nil && res.Status == 404 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		commits[i].Note = note.Message
+		return nil
+	})
+	if err != nil {
+		return nil, res, err
+	}
+	return commits, res, nil
+}
+
+func (s *gitService) ListRefs(ctx context.Context, repo, pattern string) ([]*structs.Reference, *api.Response, error) {
+	// the server matches references by prefix, so only the
+	// literal prefix of a glob pattern is sent.
+	prefix := pattern
+	glob := strings.IndexAny(pattern, `*?[\`)
+	if glob != -1 {
+		prefix = pattern[:glob]
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/git/refs", repo)
+	if prefix = strings.TrimPrefix(prefix, "refs/"); prefix != "" {
+		path += "/" + prefix
+	}
+	out := refList{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil || glob == -1 {
+		return out, res, err
+	}
+	pattern = api.ExpandRef(pattern, "refs")
+	refs := []*structs.Reference{}
+	for _, v := range out {
+		if api.MatchRef(pattern, v.Ref) {
+			refs = append(refs, v)
+		}
+	}
+	return refs, res, nil
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, _ api.ListOptions) ([]*api.Reference, *api.Response, error) {
@@ -95,21 +191,34 @@ type (
 
 	// magit commit object.
 	commit struct {
-		ID        string    `json:"id"`
-		Sha       string    `json:"sha"`
-		Message   string    `json:"message"`
-		URL       string    `json:"url"`
-		Author    signature `json:"author"`
-		Committer signature `json:"committer"`
-		Timestamp time.Time `json:"timestamp"`
+		ID           string        `json:"id"`
+		Sha          string        `json:"sha"`
+		Message      string        `json:"message"`
+		URL          string        `json:"url"`
+		Author       signature     `json:"author"`
+		Committer    signature     `json:"committer"`
+		Verification *verification `json:"verification"`
+		Timestamp    time.Time     `json:"timestamp"`
+	}
+
+	// magit commit signature verification object.
+	verification struct {
+		Verified  bool       `json:"verified"`
+		Reason    string     `json:"reason"`
+		Signature string     `json:"signature"`
+		Signer    *signature `json:"signer"`
+		Payload   string     `json:"payload"`
 	}
 
 	// magit commit info object.
 	commitInfo struct {
-		Sha       string `json:"sha"`
-		Commit    commit `json:"commit"`
-		Author    user   `json:"author"`
-		Committer user   `json:"committer"`
+		Sha       string                         `json:"sha"`
+		Commit    commit                         `json:"commit"`
+		Author    user                           `json:"author"`
+		Committer user                           `json:"committer"`
+		Parents   []*structs.CommitMeta          `json:"parents"`
+		Files     []*structs.CommitAffectedFiles `json:"files"`
+		Stats     *structs.CommitStats           `json:"stats"`
 	}
 
 	// magit signature object.
@@ -119,6 +228,10 @@ type (
 		Username string `json:"username"`
 	}
 
+	// magit reference list, which is a single object if the
+	// name matches exactly one reference.
+	refList []*structs.Reference
+
 	// magit tag object
 	tag struct {
 		Ref    string `json:"ref"`
@@ -131,6 +244,18 @@ type (
 	}
 )
 
+func (l *refList) UnmarshalJSON(data []byte) error {
+	if len(data) != 0 && data[0] == '{' {
+		ref := new(structs.Reference)
+		if err := json.Unmarshal(data, ref); err != nil {
+			return err
+		}
+		*l = refList{ref}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]*structs.Reference)(l))
+}
+
 //
 // native data structure conversion
 //
@@ -161,12 +286,40 @@ func convertCommitList(src []*commitInfo) []*api.Commit {
 
 func convertCommitInfo(src *commitInfo) *api.Commit {
 	return &api.Commit{
-		Sha:       src.Sha,
-		Link:      src.Commit.URL,
-		Message:   src.Commit.Message,
-		Author:    convertUserSignature(src.Author),
-		Committer: convertUserSignature(src.Committer),
+		Sha:          src.Sha,
+		Link:         src.Commit.URL,
+		Message:      src.Commit.Message,
+		Author:       convertUserSignature(src.Author),
+		Committer:    convertUserSignature(src.Committer),
+		Verification: convertVerification(src.Commit.Verification),
+		Parents:      convertCommitParents(src.Parents),
+		Stats:        src.Stats,
+		Files:        src.Files,
+	}
+}
+
+func convertCommitParents(src []*structs.CommitMeta) []string {
+	var dst []string
+	for _, v := range src {
+		dst = append(dst, v.SHA)
+	}
+	return dst
+}
+
+func convertVerification(src *verification) *api.Verification {
+	if src == nil {
+		return nil
 	}
+	dst := &api.Verification{
+		Signed:    src.Signature != "",
+		Verified:  src.Verified,
+		Reason:    src.Reason,
+		Signature: src.Signature,
+	}
+	if src.Signer != nil {
+		dst.Signer = convertSignature(*src.Signer)
+	}
+	return dst
 }
 
 func convertSignature(src signature) api.Signature {
@@ -10,8 +10,10 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
 )
@@ -70,6 +72,279 @@ func TestGitListCommits(t *testing.T) {
 	}
 }
 
+func TestGitListCommits_Filters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/commits").
+		MatchParam("sha", "main").
+		MatchParam("path", "routers/api").
+		MatchParam("author", "lewiscowles@me.com").
+		MatchParam("since", "2018-09-01T00:00:00Z").
+		MatchParam("until", "2018-09-30T00:00:00Z").
+		MatchParam("stat", "true").
+		MatchParam("files", "true").
+		MatchParam("page", "1").
+		MatchParam("limit", "50").
+		Reply(200).
+		Type("application/json").
+		SetHeader("X-Total-Count", "120").
+		SetHeader("X-HasMore", "true").
+		File("testdata/commits_stats.json")
+
+	opts := api.CommitListOptions{
+		Ref:    "main",
+		Path:   "routers/api",
+		Author: "lewiscowles@me.com",
+		Since:  time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2018, time.September, 30, 0, 0, 0, 0, time.UTC),
+		Stats:  true,
+		Files:  true,
+		Page:   1,
+		Size:   50,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Git.ListCommits(context.Background(), "go-magit/magit", opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*api.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/commits_stats.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !res.Page.Truncated {
+		t.Errorf("Want truncated commit list")
+	}
+	if got, want := res.Page.Total, 120; got != want {
+		t.Errorf("Want total %d, got %d", want, got)
+	}
+}
+
+func TestGitListCommits_NextURL(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/commits").
+		MatchParam("cursor", "abc").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	opts := api.CommitListOptions{
+		URL: "https://example.gitbundle.com/api/v1/repos/go-magit/magit/commits?cursor=abc",
+	}
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Git.ListCommits(context.Background(), "go-magit/magit", opts)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestGitListCommits_Notes(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/commits").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits_notes.json")
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/notes/c43399cad8766ee521b873a32c1652407c5a4630").
+		Reply(200).
+		Type("application/json").
+		File("testdata/note.json")
+
+	// a commit without a note is not found.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/notes/d293a2b9d6722dffde7998c953c3087e47a38a83").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"object does not exist"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.ListCommits(context.Background(), "go-magit/magit", api.CommitListOptions{Notes: true})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/commits_notes.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestGitListCommits_NotesError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/commits").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[
+			{"sha":"c43399cad8766ee521b873a32c1652407c5a4630"},
+			{"sha":"d293a2b9d6722dffde7998c953c3087e47a38a83"},
+			{"sha":"f05f642b892d59a0a9ef6a31f6c905a24b5db13a"}
+		]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/notes/c43399cad8766ee521b873a32c1652407c5a4630").
+		Reply(200).
+		Type("application/json").
+		File("testdata/note.json")
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/notes/d293a2b9d6722dffde7998c953c3087e47a38a83").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"object does not exist"}`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/notes/f05f642b892d59a0a9ef6a31f6c905a24b5db13a").
+		Reply(500).
+		Type("application/json").
+		BodyString(`{"message":"internal server error"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.ListCommits(context.Background(), "go-magit/magit", api.CommitListOptions{Notes: true})
+	if err == nil {
+		t.Errorf("Expect error requesting a commit note")
+	}
+	if got != nil {
+		t.Errorf("Expect no commits when a note request fails")
+	}
+}
+
+func TestGitFindNote(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/notes/c43399cad8766ee521b873a32c1652407c5a4630").
+		Reply(200).
+		Type("application/json").
+		File("testdata/note.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.FindNote(context.Background(), "go-magit/magit", "c43399cad8766ee521b873a32c1652407c5a4630")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(structs.Note)
+	raw, _ := ioutil.ReadFile("testdata/note.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got, want := got.Commit.SHA, "c43399cad8766ee521b873a32c1652407c5a4630"; got != want {
+		t.Errorf("Want note commit %s, got %s", want, got)
+	}
+}
+
+func TestGitFindNoteNotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/notes/c43399cad8766ee521b873a32c1652407c5a4630").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"object does not exist"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Git.FindNote(context.Background(), "go-magit/magit", "c43399cad8766ee521b873a32c1652407c5a4630")
+	if err == nil {
+		t.Errorf("Expect error for a commit without a note")
+	}
+}
+
+func TestGitDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/commits/f05f642b892d59a0a9ef6a31f6c905a24b5db13a.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/commit.diff")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.Git.Diff(context.Background(), "go-magit/magit", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	got, _ := ioutil.ReadAll(rc)
+	want, _ := ioutil.ReadFile("testdata/commit.diff")
+	if diff := cmp.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitPatch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/commits/f05f642b892d59a0a9ef6a31f6c905a24b5db13a.patch").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/commit.patch")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.Git.Patch(context.Background(), "go-magit/magit", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	got, _ := ioutil.ReadAll(rc)
+	want, _ := ioutil.ReadFile("testdata/commit.patch")
+	if diff := cmp.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitDiffNotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/commits/0000000000000000000000000000000000000000.diff").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"object does not exist"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, res, err := client.Git.Diff(context.Background(), "go-magit/magit", api.EmptyCommit)
+	if err == nil {
+		t.Errorf("Expect error for a missing commit")
+	}
+	if res == nil || res.Status != 404 {
+		t.Errorf("Expect 404 response")
+	}
+}
+
 func TestGitListChanges(t *testing.T) {
 	client, _ := New("https://example.gitbundle.com")
 	_, _, err := client.Git.ListChanges(context.Background(), "go-magit/magit", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a", api.ListOptions{})
@@ -176,6 +451,221 @@ func TestGitFindTag(t *testing.T) {
 	}
 }
 
+//
+// reference sub-tests
+//
+
+func TestGitFindRef(t *testing.T) {
+	defer gock.Off()
+
+	// the server matches by prefix and returns nested
+	// references along with the exact match.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs/heads/release/1.0").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs_release.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.FindRef(context.Background(), "go-magit/magit", "refs/heads/release/1.0")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/refs_release.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want[:1]); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindRef_Object(t *testing.T) {
+	defer gock.Off()
+
+	// the server returns a single object if the name matches
+	// exactly one reference.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs/heads/main").
+		Reply(200).
+		Type("application/json").
+		File("testdata/ref.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.FindRef(context.Background(), "go-magit/magit", "heads/main")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(structs.Reference)
+	raw, _ := ioutil.ReadFile("testdata/ref.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, []*structs.Reference{want}); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindRef_Prefix(t *testing.T) {
+	defer gock.Off()
+
+	// no reference has the exact name, so the references
+	// nested below the name are returned.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs/heads/release").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs_release.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.FindRef(context.Background(), "go-magit/magit", "heads/release")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/refs_release.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindRef_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	// the references only share the prefix of the name.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs/heads/rel").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs_release.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Git.FindRef(context.Background(), "go-magit/magit", "refs/heads/rel")
+	if err != api.ErrNotFound {
+		t.Errorf("Expect Not Found error, got %v", err)
+	}
+}
+
+func TestGitListRefs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs$").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.ListRefs(context.Background(), "go-magit/magit", "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/refs.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitListRefs_Prefix(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs/heads/release").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs_release.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.ListRefs(context.Background(), "go-magit/magit", "refs/heads/release")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/refs_release.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitListRefs_Glob(t *testing.T) {
+	defer gock.Off()
+
+	// only the literal prefix of the pattern is sent to the
+	// server, and the results are filtered by the pattern.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs/heads/release/$").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs_release.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.ListRefs(context.Background(), "go-magit/magit", "refs/heads/release/*")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/refs_release.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestGitListRefs_GlobUnqualified(t *testing.T) {
+	defer gock.Off()
+
+	// the pattern is expanded to a fully qualified reference
+	// path before the results are filtered.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/git/refs/heads/release/$").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs_release.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Git.ListRefs(context.Background(), "go-magit/magit", "heads/release/*")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/refs_release.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
 func TestGitListTags(t *testing.T) {
 	defer gock.Off()
 
@@ -7,10 +7,13 @@ package impl
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type issueService struct {
@@ -30,6 +33,15 @@ func (s *issueService) FindComment(ctx context.Context, repo string, index, id i
 
 func (s *issueService) List(ctx context.Context, repo string, opts api.IssueListOptions) ([]*api.Issue, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues?%s", repo, encodeIssueListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*issue{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertIssueList(out), res, err
+}
+
+func (s *issueService) Search(ctx context.Context, opts api.IssueSearchOptions) ([]*api.Issue, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/issues/search?%s", encodeIssueSearchOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*issue{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertIssueList(out), res, err
@@ -37,11 +49,20 @@ func (s *issueService) List(ctx context.Context, repo string, opts api.IssueList
 
 func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts api.ListOptions) ([]*api.Comment, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/comments?%s", repo, index, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*issueComment{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertIssueCommentList(out), res, err
 }
 
+func (s *issueService) ListTimeline(ctx context.Context, repo string, index int, since time.Time, opts api.ListOptions) ([]*api.TimelineEvent, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/timeline?%s", repo, index, encodeTimelineListOptions(since, opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.TimelineComment{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertTimeline(out), res, err
+}
+
 func (s *issueService) Create(ctx context.Context, repo string, input *api.IssueInput) (*api.Issue, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues", repo)
 	in := &issueInput{
@@ -53,6 +74,20 @@ func (s *issueService) Create(ctx context.Context, repo string, input *api.Issue
 	return convertIssue(out), res, err
 }
 
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *api.IssueUpdateInput) (*api.Issue, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d", repo, number)
+	// the server leaves the title unchanged if it is empty.
+	in := &structs.EditIssueOption{
+		Body: input.Body,
+	}
+	if input.Title != nil {
+		in.Title = *input.Title
+	}
+	out := new(issue)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertIssue(out), res, err
+}
+
 func (s *issueService) CreateComment(ctx context.Context, repo string, index int, input *api.CommentInput) (*api.Comment, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/comments", repo, index)
 	in := &issueCommentInput{
@@ -63,6 +98,16 @@ func (s *issueService) CreateComment(ctx context.Context, repo string, index int
 	return convertIssueComment(out), res, err
 }
 
+func (s *issueService) UpdateComment(ctx context.Context, repo string, index, id int, input *api.CommentInput) (*api.Comment, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/comments/%d", repo, id)
+	in := &structs.EditIssueCommentOption{
+		Body: input.Body,
+	}
+	out := new(issueComment)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertIssueComment(out), res, err
+}
+
 func (s *issueService) DeleteComment(ctx context.Context, repo string, index, id int) (*api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/comments/%d", repo, index, id)
 	return s.client.do(ctx, "DELETE", path, nil, nil)
@@ -80,6 +125,108 @@ func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*ap
 	return nil, api.ErrNotSupported
 }
 
+func (s *issueService) ListReactions(ctx context.Context, repo string, number int, opts api.ListOptions) ([]*structs.Reaction, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/reactions?%s", repo, number, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.Reaction{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *issueService) AddReaction(ctx context.Context, repo string, number int, reaction string) (*structs.Reaction, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/reactions", repo, number)
+	in := &structs.EditReactionOption{Reaction: reaction}
+	out := new(structs.Reaction)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *issueService) RemoveReaction(ctx context.Context, repo string, number int, reaction string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/reactions", repo, number)
+	in := &structs.EditReactionOption{Reaction: reaction}
+	return s.client.do(ctx, "DELETE", path, in, nil)
+}
+
+func (s *issueService) ListCommentReactions(ctx context.Context, repo string, id int, opts api.ListOptions) ([]*structs.Reaction, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/comments/%d/reactions?%s", repo, id, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.Reaction{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *issueService) AddCommentReaction(ctx context.Context, repo string, id int, reaction string) (*structs.Reaction, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/comments/%d/reactions", repo, id)
+	in := &structs.EditReactionOption{Reaction: reaction}
+	out := new(structs.Reaction)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *issueService) RemoveCommentReaction(ctx context.Context, repo string, id int, reaction string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/comments/%d/reactions", repo, id)
+	in := &structs.EditReactionOption{Reaction: reaction}
+	return s.client.do(ctx, "DELETE", path, in, nil)
+}
+
+func (s *issueService) ListAttachments(ctx context.Context, repo string, number int, opts api.ListOptions) ([]*structs.Attachment, *api.Response, error) {
+	return s.attachments(repo, number).list(ctx, opts)
+}
+
+func (s *issueService) FindAttachment(ctx context.Context, repo string, number int, id int64) (*structs.Attachment, *api.Response, error) {
+	return s.attachments(repo, number).find(ctx, id)
+}
+
+func (s *issueService) UploadAttachment(ctx context.Context, repo string, number int, name string, r io.Reader) (*structs.Attachment, *api.Response, error) {
+	return s.attachments(repo, number).upload(ctx, name, r)
+}
+
+func (s *issueService) UpdateAttachment(ctx context.Context, repo string, number int, id int64, in *structs.EditAttachmentOptions) (*structs.Attachment, *api.Response, error) {
+	return s.attachments(repo, number).update(ctx, id, in)
+}
+
+func (s *issueService) DeleteAttachment(ctx context.Context, repo string, number int, id int64) (*api.Response, error) {
+	return s.attachments(repo, number).delete(ctx, id)
+}
+
+func (s *issueService) DownloadAttachment(ctx context.Context, repo string, number int, id int64) (io.ReadCloser, *api.Response, error) {
+	return s.attachments(repo, number).download(ctx, id)
+}
+
+func (s *issueService) ListCommentAttachments(ctx context.Context, repo string, comment int, opts api.ListOptions) ([]*structs.Attachment, *api.Response, error) {
+	return s.commentAttachments(repo, comment).list(ctx, opts)
+}
+
+func (s *issueService) FindCommentAttachment(ctx context.Context, repo string, comment int, id int64) (*structs.Attachment, *api.Response, error) {
+	return s.commentAttachments(repo, comment).find(ctx, id)
+}
+
+func (s *issueService) UploadCommentAttachment(ctx context.Context, repo string, comment int, name string, r io.Reader) (*structs.Attachment, *api.Response, error) {
+	return s.commentAttachments(repo, comment).upload(ctx, name, r)
+}
+
+func (s *issueService) UpdateCommentAttachment(ctx context.Context, repo string, comment int, id int64, in *structs.EditAttachmentOptions) (*structs.Attachment, *api.Response, error) {
+	return s.commentAttachments(repo, comment).update(ctx, id, in)
+}
+
+func (s *issueService) DeleteCommentAttachment(ctx context.Context, repo string, comment int, id int64) (*api.Response, error) {
+	return s.commentAttachments(repo, comment).delete(ctx, id)
+}
+
+func (s *issueService) DownloadCommentAttachment(ctx context.Context, repo string, comment int, id int64) (io.ReadCloser, *api.Response, error) {
+	return s.commentAttachments(repo, comment).download(ctx, id)
+}
+
+func (s *issueService) attachments(repo string, number int) attachments {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/assets", repo, number)
+	return attachments{s.client, path}
+}
+
+func (s *issueService) commentAttachments(repo string, comment int) attachments {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/comments/%d/assets", repo, comment)
+	return attachments{s.client, path}
+}
+
 //
 // native data structures
 //
@@ -95,6 +242,7 @@ type (
 		State       string    `json:"state"`
 		Labels      []string  `json:"labels"`
 		Comments    int       `json:"comments"`
+		Reactions   reactions `json:"reactions"`
 		Created     time.Time `json:"created_at"`
 		Updated     time.Time `json:"updated_at"`
 		PullRequest *struct {
@@ -115,6 +263,7 @@ type (
 		HTMLURL   string    `json:"html_url"`
 		User      user      `json:"user"`
 		Body      string    `json:"body"`
+		Reactions reactions `json:"reactions"`
 		CreatedAt time.Time `json:"created_at"`
 		UpdatedAt time.Time `json:"updated_at"`
 	}
@@ -123,8 +272,28 @@ type (
 	issueCommentInput struct {
 		Body string `json:"body"`
 	}
+
+	// magit aggregated reaction counts, keyed by reaction
+	// content.
+	reactions map[string]int
 )
 
+// UnmarshalJSON unmarshals the reaction counts, ignoring
+// summary fields such as total_count and url.
+func (r *reactions) UnmarshalJSON(data []byte) error {
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = reactions{}
+	for k, v := range raw {
+		if n, ok := v.(float64); ok && k != "total_count" && n > 0 {
+			(*r)[k] = int(n)
+		}
+	}
+	return nil
+}
+
 //
 // native data structure conversion
 //
@@ -139,14 +308,15 @@ func convertIssueList(from []*issue) []*api.Issue {
 
 func convertIssue(from *issue) *api.Issue {
 	return &api.Issue{
-		Number:  from.Number,
-		Title:   from.Title,
-		Body:    from.Body,
-		Link:    "", // TODO construct the link to the issue.
-		Closed:  from.State == "closed",
-		Author:  *convertUser(&from.User),
-		Created: from.Created,
-		Updated: from.Updated,
+		Number:    from.Number,
+		Title:     from.Title,
+		Body:      from.Body,
+		Link:      "", // TODO construct the link to the issue.
+		Closed:    from.State == "closed",
+		Author:    *convertUser(&from.User),
+		Reactions: convertReactions(from.Reactions),
+		Created:   from.Created,
+		Updated:   from.Updated,
 	}
 }
 
@@ -160,10 +330,22 @@ func convertIssueCommentList(from []*issueComment) []*api.Comment {
 
 func convertIssueComment(from *issueComment) *api.Comment {
 	return &api.Comment{
-		ID:      from.ID,
-		Body:    from.Body,
-		Author:  *convertUser(&from.User),
-		Created: from.CreatedAt,
-		Updated: from.UpdatedAt,
+		ID:        from.ID,
+		Body:      from.Body,
+		Author:    *convertUser(&from.User),
+		Reactions: convertReactions(from.Reactions),
+		Created:   from.CreatedAt,
+		Updated:   from.UpdatedAt,
 	}
 }
+
+func convertReactions(from reactions) api.Reactions {
+	if len(from) == 0 {
+		return nil
+	}
+	to := api.Reactions{}
+	for k, v := range from {
+		to[k] = v
+	}
+	return to
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+	"gopkg.in/yaml.v2"
+)
+
+//
+// native data structures
+//
+
+type (
+	// issue template, including the body of issue forms
+	// returned by newer server versions.
+	issueTemplate struct {
+		structs.IssueTemplate
+		Body []*issueFormField `json:"body" yaml:"body"`
+	}
+
+	issueFormField struct {
+		Type        string                 `json:"type" yaml:"type"`
+		ID          string                 `json:"id" yaml:"id"`
+		Attributes  map[string]interface{} `json:"attributes" yaml:"attributes"`
+		Validations map[string]interface{} `json:"validations" yaml:"validations"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertIssueTemplateList(from []*issueTemplate) []*api.IssueTemplate {
+	to := []*api.IssueTemplate{}
+	for _, v := range from {
+		to = append(to, convertIssueTemplate(v))
+	}
+	return to
+}
+
+func convertIssueTemplate(from *issueTemplate) *api.IssueTemplate {
+	to := &api.IssueTemplate{
+		Name:     from.Name,
+		About:    from.About,
+		Title:    from.Title,
+		Labels:   from.Labels,
+		Ref:      from.Ref,
+		FileName: from.FileName,
+	}
+	fields := from.Body
+	if len(fields) == 0 && isYAML(from.FileName) {
+		// older server versions return the raw issue
+		// form as the template content.
+		form := new(issueTemplate)
+		if err := yaml.Unmarshal([]byte(from.Content), form); err == nil {
+			fields = form.Body
+		}
+	}
+	if len(fields) == 0 {
+		to.Body = from.Content
+		return to
+	}
+	for _, field := range fields {
+		to.Fields = append(to.Fields, convertIssueFormField(field))
+	}
+	return to
+}
+
+func convertIssueFormField(from *issueFormField) *api.IssueFormField {
+	to := &api.IssueFormField{
+		Type:        from.Type,
+		ID:          from.ID,
+		Label:       stringValue(from.Attributes["label"]),
+		Description: stringValue(from.Attributes["description"]),
+		Value:       stringValue(from.Attributes["value"]),
+		Required:    boolValue(from.Validations["required"]),
+	}
+	options, _ := from.Attributes["options"].([]interface{})
+	for _, option := range options {
+		switch v := option.(type) {
+		case map[string]interface{}:
+			to.Options = append(to.Options, &api.IssueFormOption{
+				Label:    stringValue(v["label"]),
+				Required: boolValue(v["required"]),
+			})
+		case map[interface{}]interface{}:
+			to.Options = append(to.Options, &api.IssueFormOption{
+				Label:    stringValue(v["label"]),
+				Required: boolValue(v["required"]),
+			})
+		default:
+			to.Options = append(to.Options, &api.IssueFormOption{
+				Label: stringValue(v),
+			})
+		}
+	}
+	return to
+}
+
+func isYAML(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yml", ".yaml":
+		return true
+	}
+	return false
+}
+
+func stringValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func boolValue(v interface{}) bool {
+	b, _ := v.(bool)
+	return b
+}
@@ -9,9 +9,12 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
 )
@@ -70,6 +73,123 @@ func TestIssueList(t *testing.T) {
 	}
 }
 
+func TestIssueList_Filters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues").
+		MatchParam("state", "all").
+		MatchParam("labels", "bug,ui").
+		MatchParam("milestones", "v1.0").
+		MatchParam("assigned_by", "janedoe").
+		MatchParam("created_by", "johnsmith").
+		MatchParam("mentioned_by", "octocat").
+		MatchParam("since", "2023-03-01T00:00:00Z").
+		MatchParam("before", "2023-04-01T00:00:00Z").
+		MatchParam("type", "pulls").
+		MatchParam("q", "crash").
+		MatchParam("sort", "oldest").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	opts := api.IssueListOptions{
+		Open:       true,
+		Closed:     true,
+		Labels:     []string{"bug", "ui"},
+		Milestones: []string{"v1.0"},
+		Assignee:   "janedoe",
+		Author:     "johnsmith",
+		Mentioned:  "octocat",
+		Since:      time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		Before:     time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+		Type:       api.IssueTypePull,
+		Keyword:    "crash",
+		Sort:       api.SortOldest,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Issues.List(context.Background(), "go-magit/magit", opts); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIssueList_URL(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues").
+		MatchParam("cursor", "abc").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	opts := api.IssueListOptions{
+		Page: 2,
+		URL:  "https://example.gitbundle.com/api/v1/repos/go-magit/magit/issues?cursor=abc",
+	}
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Issues.List(context.Background(), "go-magit/magit", opts); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIssueList_ForeignURL(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	opts := api.IssueListOptions{
+		Page: 2,
+		URL:  "https://attacker.example.com/api/v1/repos/go-magit/magit/issues?cursor=abc",
+	}
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Issues.List(context.Background(), "go-magit/magit", opts); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIssueSearch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/issues/search").
+		MatchParam("type", "issues").
+		MatchParam("labels", "bug").
+		MatchParam("owner", "go-magit").
+		MatchParam("assigned", "true").
+		MatchParam("q", "crash").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	opts := api.IssueSearchOptions{
+		Labels:   []string{"bug"},
+		Type:     api.IssueTypeIssue,
+		Keyword:  "crash",
+		Owner:    "go-magit",
+		Assigned: true,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.Search(context.Background(), opts)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.Issue{}
+	raw, _ := ioutil.ReadFile("testdata/issues.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestIssueCreate(t *testing.T) {
 	defer gock.Off()
 
@@ -190,6 +310,141 @@ func TestIssueCommentCreate(t *testing.T) {
 	}
 }
 
+func TestIssueCommentUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/issues/comments/74").
+		MatchType("json").
+		JSON(map[string]string{"body": "what?"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/comment.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.UpdateComment(context.Background(), "go-magit/magit", 1, 74, &api.CommentInput{Body: "what?"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/issues/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"title":          "Bug found",
+			"body":           "I'm having a problem with this.",
+			"ref":            nil,
+			"assignee":       nil,
+			"assignees":      nil,
+			"milestone":      nil,
+			"state":          nil,
+			"due_date":       nil,
+			"unset_due_date": nil,
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	title, body := "Bug found", "I'm having a problem with this."
+	input := &api.IssueUpdateInput{
+		Title: &title,
+		Body:  &body,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.Update(context.Background(), "go-magit/magit", 1, input)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.Issue)
+	raw, _ := ioutil.ReadFile("testdata/issue.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueUpdate_ClearBody(t *testing.T) {
+	defer gock.Off()
+
+	// the title is left unchanged and the body is cleared.
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/issues/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"title":          "",
+			"body":           "",
+			"ref":            nil,
+			"assignee":       nil,
+			"assignees":      nil,
+			"milestone":      nil,
+			"state":          nil,
+			"due_date":       nil,
+			"unset_due_date": nil,
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	body := ""
+	input := &api.IssueUpdateInput{Body: &body}
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Issues.Update(context.Background(), "go-magit/magit", 1, input); err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestIssueUpdate_Title(t *testing.T) {
+	defer gock.Off()
+
+	// the body is left unchanged.
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/issues/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"title":          "Bug found",
+			"body":           nil,
+			"ref":            nil,
+			"assignee":       nil,
+			"assignees":      nil,
+			"milestone":      nil,
+			"state":          nil,
+			"due_date":       nil,
+			"unset_due_date": nil,
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	title := "Bug found"
+	input := &api.IssueUpdateInput{Title: &title}
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.Issues.Update(context.Background(), "go-magit/magit", 1, input); err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
 func TestIssueCommentDelete(t *testing.T) {
 	defer gock.Off()
 
@@ -204,3 +459,209 @@ func TestIssueCommentDelete(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+//
+// attachment sub-tests
+//
+
+func TestIssueUploadAttachment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/issues/2/assets").
+		MatchParam("name", "trace.log").
+		Reply(201).
+		Type("application/json").
+		File("testdata/release_asset.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.UploadAttachment(context.Background(), "go-magit/magit", 2, "trace.log", strings.NewReader("trace"))
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := got.ID, int64(3); got != want {
+		t.Errorf("Want attachment id %d, got %d", want, got)
+	}
+}
+
+func TestIssueListAttachments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/1/assets").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/release_assets.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Issues.ListAttachments(context.Background(), "go-magit/magit", 1, api.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.Attachment{}
+	raw, _ := ioutil.ReadFile("testdata/release_assets.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestIssueListCommentAttachments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/comments/5/assets").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/release_assets.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.ListCommentAttachments(context.Background(), "go-magit/magit", 5, api.ListOptions{Page: 2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.Attachment{}
+	raw, _ := ioutil.ReadFile("testdata/release_assets.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueUpdateAttachment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/issues/2/assets/3").
+		MatchType("json").
+		JSON(&structs.EditAttachmentOptions{Name: "app-linux-amd64.tar.gz"}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/release_asset.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.UpdateAttachment(context.Background(), "go-magit/magit", 2, 3, &structs.EditAttachmentOptions{Name: "app-linux-amd64.tar.gz"})
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := got.Name, "app-linux-amd64.tar.gz"; got != want {
+		t.Errorf("Want attachment name %q, got %q", want, got)
+	}
+}
+
+//
+// reaction sub-tests
+//
+
+func TestIssueListReactions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/2/reactions").
+		Reply(200).
+		Type("application/json").
+		File("testdata/reactions.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.ListReactions(context.Background(), "go-magit/magit", 2, api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.Reaction{}
+	raw, _ := ioutil.ReadFile("testdata/reactions.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueListCommentReactions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/comments/74/reactions").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/reactions.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Issues.ListCommentReactions(context.Background(), "go-magit/magit", 74, api.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.Reaction{}
+	raw, _ := ioutil.ReadFile("testdata/reactions.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestIssueAddCommentReaction(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/issues/comments/74/reactions").
+		MatchType("json").
+		JSON(&structs.EditReactionOption{Reaction: "eyes"}).
+		Reply(201).
+		Type("application/json").
+		BodyString(`{"user":{"id":1,"login":"jcitizen"},"content":"eyes","created_at":"2023-03-01T08:00:00Z"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Issues.AddCommentReaction(context.Background(), "go-magit/magit", 74, "eyes")
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := got.Reaction, "eyes"; got != want {
+		t.Errorf("Want reaction %q, got %q", want, got)
+	}
+}
+
+func TestIssueRemoveReaction(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/issues/2/reactions").
+		MatchType("json").
+		JSON(&structs.EditReactionOption{Reaction: "eyes"}).
+		Reply(200)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, err := client.Issues.RemoveReaction(context.Background(), "go-magit/magit", 2, "eyes")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReactionsTotal(t *testing.T) {
+	reactions := api.Reactions{"+1": 2, "eyes": 1}
+	if got, want := reactions.Total(), 3; got != want {
+		t.Errorf("Want total %d, got %d", want, got)
+	}
+}
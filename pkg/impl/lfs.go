@@ -0,0 +1,179 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// lfsMediaType is the media type of Git LFS API requests
+// and responses.
+const lfsMediaType = "application/vnd.git-lfs+json"
+
+type lfsService struct {
+	client *wrapper
+}
+
+func (s *lfsService) CreateLock(ctx context.Context, repo string, in *structs.LFSLockRequest) (*structs.LFSLock, *api.Response, error) {
+	path := fmt.Sprintf("%s.git/info/lfs/locks", repo)
+	out := new(structs.LFSLockResponse)
+	res, err := s.do(ctx, "POST", path, in, out)
+	return out.Lock, res, err
+}
+
+func (s *lfsService) ListLocks(ctx context.Context, repo string, opts api.LFSLockListOptions) (*structs.LFSLockList, *api.Response, error) {
+	path := fmt.Sprintf("%s.git/info/lfs/locks?%s", repo, encodeLFSLockListOptions(opts))
+	out := new(structs.LFSLockList)
+	res, err := s.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *lfsService) VerifyLocks(ctx context.Context, repo string, opts api.LFSLockVerifyOptions) (*structs.LFSLockListVerify, *api.Response, error) {
+	path := fmt.Sprintf("%s.git/info/lfs/locks/verify", repo)
+	in := &lfsLockVerifyRequest{
+		Cursor: opts.Cursor,
+		Limit:  opts.Size,
+	}
+	if opts.Refspec != "" {
+		in.Ref = &structs.LFSLockRef{Name: opts.Refspec}
+	}
+	out := new(structs.LFSLockListVerify)
+	res, err := s.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *lfsService) Unlock(ctx context.Context, repo, id string, force bool) (*structs.LFSLock, *api.Response, error) {
+	path := fmt.Sprintf("%s.git/info/lfs/locks/%s/unlock", repo, url.PathEscape(id))
+	in := &structs.LFSLockDeleteRequest{Force: force}
+	out := new(structs.LFSLockResponse)
+	res, err := s.do(ctx, "POST", path, in, out)
+	return out.Lock, res, err
+}
+
+func (s *lfsService) Batch(ctx context.Context, repo string, in *structs.LFSBatchRequest) (*structs.LFSBatchResponse, *api.Response, error) {
+	path := fmt.Sprintf("%s.git/info/lfs/objects/batch", repo)
+	out := new(structs.LFSBatchResponse)
+	res, err := s.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *lfsService) Download(ctx context.Context, action *structs.LFSAction, offset int64) (io.ReadCloser, *api.Response, error) {
+	header := actionHeader(action)
+	if offset != 0 {
+		header.Set("Range", encodeRange(offset, 0))
+	}
+	return s.client.stream(ctx, "GET", action.Href, header)
+}
+
+func (s *lfsService) Upload(ctx context.Context, action *structs.LFSAction, r io.Reader, size int64) (*api.Response, error) {
+	req := &api.Request{
+		Method: "PUT",
+		Path:   action.Href,
+		Header: actionHeader(action),
+		Body:   r,
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Length", strconv.FormatInt(size, 10))
+
+	// execute the http request
+	res, err := s.client.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return res, res.Decode(ctx, nil)
+}
+
+func (s *lfsService) Verify(ctx context.Context, action *structs.LFSAction, obj *structs.LFSPointer) (*api.Response, error) {
+	buf := new(bytes.Buffer)
+	_ = json.NewEncoder(buf).Encode(obj)
+	req := &api.Request{
+		Method: "POST",
+		Path:   action.Href,
+		Header: actionHeader(action),
+		Body:   buf,
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+
+	// execute the http request
+	res, err := s.client.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return res, res.Decode(ctx, nil)
+}
+
+// do sends a Git LFS API request, using the Git LFS media type,
+// and unmarshals the response. A conflict response is returned
+// as a *api.LockConflictError.
+func (s *lfsService) do(ctx context.Context, method, path string, in, out interface{}) (*api.Response, error) {
+	req := &api.Request{
+		Method: method,
+		Path:   path,
+		Header: map[string][]string{},
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	if in != nil {
+		buf := new(bytes.Buffer)
+		_ = json.NewEncoder(buf).Encode(in)
+		req.Header.Set("Content-Type", lfsMediaType)
+		req.Body = buf
+	}
+
+	// execute the http request
+	res, err := s.client.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.Status == http.StatusConflict {
+		lockErr := new(structs.LFSLockError)
+		if err := json.NewDecoder(res.Body).Decode(lockErr); err != nil || lockErr.Message == "" {
+			lockErr.Message = http.StatusText(res.Status)
+		}
+		return res, &api.LockConflictError{
+			Message: lockErr.Message,
+			Lock:    lockErr.Lock,
+		}
+	}
+	return res, res.Decode(ctx, out)
+}
+
+// actionHeader returns the request headers of a transfer
+// action. The action headers usually carry the authorization
+// of the transfer, which is not overwritten by the client
+// transport.
+func actionHeader(action *structs.LFSAction) http.Header {
+	header := http.Header{}
+	for k, v := range action.Header {
+		header.Set(k, v)
+	}
+	return header
+}
+
+//
+// native data structures
+//
+
+type lfsLockVerifyRequest struct {
+	Cursor string              `json:"cursor,omitempty"`
+	Limit  int                 `json:"limit,omitempty"`
+	Ref    *structs.LFSLockRef `json:"ref,omitempty"`
+}
@@ -10,8 +10,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	api "github.com/gitbundle/api"
 )
@@ -30,17 +34,24 @@ func New(uri string) (*api.Client, error) {
 	// initialize services
 	client.Driver = api.DriverMagit
 	client.Linker = &linker{base.String()}
+	client.Admin = &adminService{client}
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
+	client.LFS = &lfsService{client}
 	client.Milestones = &milestoneService{client}
+	client.Notifications = &notificationService{client}
 	client.Organizations = &organizationService{client}
+	client.Packages = &packageService{client}
 	client.PullRequests = &pullService{client}
 	client.Repositories = &repositoryService{client}
 	client.Releases = &releaseService{client}
 	client.Reviews = &reviewService{client}
+	client.Teams = &teamService{client}
+	client.Times = &timeService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Wiki = &wikiService{client}
 	return client.Client, nil
 }
 
@@ -81,3 +92,150 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 
 	return res, res.Decode(ctx, out)
 }
+
+// conditional wraps the Client.Do function by creating a GET
+// Request with the conditional request headers and unmarshalling
+// the response. A not modified response is not decoded and is
+// not treated as an error.
+func (c *wrapper) conditional(ctx context.Context, path, etag, modified string, out interface{}) (*api.Response, error) {
+	req := &api.Request{
+		Method: "GET",
+		Path:   path,
+		Header: map[string][]string{},
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if modified != "" {
+		req.Header.Set("If-Modified-Since", modified)
+	}
+
+	// execute the http request
+	res, err := c.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.Status == http.StatusNotModified {
+		return res, nil
+	}
+	return res, res.Decode(ctx, out)
+}
+
+// paginate returns the path of the next page url when
+// continuing a list from a previous response. Urls that do
+// not belong to the API host are ignored and the path is
+// returned unchanged.
+func (c *wrapper) paginate(path, next string) string {
+	base := c.BaseURL.String()
+	if next == "" || !strings.HasPrefix(next, base) {
+		return path
+	}
+	return strings.TrimPrefix(next, base)
+}
+
+// stream wraps the Client.Do function by creating the Request and
+// returning the unread response body. The caller is responsible
+// for closing the returned body.
+func (c *wrapper) stream(ctx context.Context, method, path string, header map[string][]string) (io.ReadCloser, *api.Response, error) {
+	req := &api.Request{
+		Method: method,
+		Path:   path,
+		Header: map[string][]string{},
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	// execute the http request
+	res, err := c.Client.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Status > 300 {
+		defer res.Body.Close()
+		return nil, res, res.Decode(ctx, nil)
+	}
+	return res.Body, res, nil
+}
+
+// upload wraps the Client.Do function by creating a multipart
+// Request that streams the file contents from r, and unmarshalling
+// the response. The file is never buffered in memory.
+func (c *wrapper) upload(ctx context.Context, path, field, name string, r io.Reader, out interface{}) (*api.Response, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile(field, name)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	req := &api.Request{
+		Method: "POST",
+		Path:   path,
+		Header: map[string][]string{},
+		Body:   pr,
+	}
+	req.Header.Add("Content-Type", mw.FormDataContentType())
+
+	// execute the http request
+	res, err := c.Client.Do(ctx, req)
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return res, res.Decode(ctx, out)
+}
+
+// maxConcurrency limits the number of concurrent requests
+// made by each.
+const maxConcurrency = 8
+
+// each calls fn for every index in [0, n), with at most
+// maxConcurrency calls in flight. The first error cancels
+// the context passed to the remaining calls and is returned.
+func each(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		err  error
+	)
+	sem := make(chan struct{}, maxConcurrency)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if e := fn(ctx, i); e != nil {
+				once.Do(func() {
+					err = e
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -7,6 +7,9 @@
 package impl
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 
 	api "github.com/gitbundle/api"
@@ -55,3 +58,51 @@ func testPage(res *api.Response) func(t *testing.T) {
 		}
 	}
 }
+
+func TestEach(t *testing.T) {
+	var running, max int32
+	got := make([]int, 20)
+	err := each(context.Background(), len(got), func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		got[i] = i
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	for i, v := range got {
+		if v != i {
+			t.Errorf("Want index %d called, got %d", i, v)
+		}
+	}
+	if max > maxConcurrency {
+		t.Errorf("Want at most %d concurrent calls, got %d", maxConcurrency, max)
+	}
+}
+
+func TestEach_Error(t *testing.T) {
+	want := errors.New("request failed")
+	var calls int32
+	err := each(context.Background(), 100, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 0 {
+			return want
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != want {
+		t.Errorf("Want error %v, got %v", want, err)
+	}
+	// the error cancels the remaining calls.
+	if calls == 100 {
+		t.Errorf("Want remaining calls cancelled")
+	}
+}
@@ -23,7 +23,8 @@ func (s *milestoneService) Find(ctx context.Context, repo string, id int) (*api.
 
 func (s *milestoneService) List(ctx context.Context, repo string, opts api.MilestoneListOptions) ([]*api.Milestone, *api.Response, error) {
 	namespace, name := api.Split(repo)
-	path := fmt.Sprintf("api/v1/repos/%s/%s/milestones%s", namespace, name, encodeMilestoneListOptions(opts))
+	path := fmt.Sprintf("api/v1/repos/%s/%s/milestones?%s", namespace, name, encodeMilestoneListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*milestone{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertMilestoneList(out), res, err
@@ -50,13 +50,15 @@ func TestMilestoneList(t *testing.T) {
 
 	gock.New("https://example.gitbundle.com").
 		Get("/api/v1/repos/jcitizen/my-repo/milestones").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
 		Reply(200).
 		Type("application/json").
 		SetHeaders(mockPageHeaders).
 		File("testdata/milestones.json")
 
 	client, _ := New("https://example.gitbundle.com")
-	got, res, err := client.Milestones.List(context.Background(), "jcitizen/my-repo", api.MilestoneListOptions{})
+	got, res, err := client.Milestones.List(context.Background(), "jcitizen/my-repo", api.MilestoneListOptions{Page: 1, Size: 30})
 	if err != nil {
 		t.Error(err)
 	}
@@ -0,0 +1,74 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type notificationService struct {
+	client *wrapper
+}
+
+func (s *notificationService) List(ctx context.Context, opts api.NotificationListOptions) ([]*structs.NotificationThread, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/notifications?%s", encodeNotificationListOptions(opts))
+	return s.list(ctx, path, opts)
+}
+
+func (s *notificationService) ListRepo(ctx context.Context, repo string, opts api.NotificationListOptions) ([]*structs.NotificationThread, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/notifications?%s", repo, encodeNotificationListOptions(opts))
+	return s.list(ctx, path, opts)
+}
+
+func (s *notificationService) Find(ctx context.Context, id int64) (*structs.NotificationThread, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/notifications/threads/%d", id)
+	out := new(structs.NotificationThread)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *notificationService) CountNew(ctx context.Context) (int64, *api.Response, error) {
+	out := new(structs.NotificationCount)
+	res, err := s.client.do(ctx, "GET", "api/v1/notifications/new", nil, out)
+	return out.New, res, err
+}
+
+func (s *notificationService) Mark(ctx context.Context, id int64, status string) (*structs.NotificationThread, *api.Response, error) {
+	params := url.Values{}
+	params.Set("to-status", status)
+	path := fmt.Sprintf("api/v1/notifications/threads/%d?%s", id, params.Encode())
+	out := new(structs.NotificationThread)
+	res, err := s.client.do(ctx, "PATCH", path, nil, out)
+	return out, res, err
+}
+
+func (s *notificationService) MarkAll(ctx context.Context, opts api.NotificationMarkOptions) ([]*structs.NotificationThread, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/notifications?%s", encodeNotificationMarkOptions(opts))
+	out := []*structs.NotificationThread{}
+	res, err := s.client.do(ctx, "PUT", path, nil, &out)
+	return out, res, err
+}
+
+func (s *notificationService) MarkRepo(ctx context.Context, repo string, opts api.NotificationMarkOptions) ([]*structs.NotificationThread, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/notifications?%s", repo, encodeNotificationMarkOptions(opts))
+	out := []*structs.NotificationThread{}
+	res, err := s.client.do(ctx, "PUT", path, nil, &out)
+	return out, res, err
+}
+
+// list returns the notification threads, making the request
+// conditional if the options hold the validators of a previous
+// response.
+func (s *notificationService) list(ctx context.Context, path string, opts api.NotificationListOptions) ([]*structs.NotificationThread, *api.Response, error) {
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.NotificationThread{}
+	res, err := s.client.conditional(ctx, path, opts.ETag, opts.LastModified, &out)
+	return out, res, err
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestNotificationList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/notifications").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		MatchParam("status-types", "unread").
+		MatchParam("subject-type", "issue").
+		MatchParam("since", "2023-04-01T00:00:00Z").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/notifications.json")
+
+	opts := api.NotificationListOptions{
+		Page:         1,
+		Size:         30,
+		Status:       []string{api.NotificationUnread},
+		SubjectTypes: []structs.NotifySubjectType{structs.NotifySubjectIssue},
+		Since:        time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Notifications.List(context.Background(), opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.NotificationThread{}
+	raw, _ := ioutil.ReadFile("testdata/notifications.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestNotificationListNotModified(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/octocat/hello-world/notifications").
+		MatchHeader("If-None-Match", `"v1"`).
+		MatchHeader("If-Modified-Since", "Sun, 02 Apr 2023 08:15:00 GMT").
+		Reply(304)
+
+	opts := api.NotificationListOptions{
+		ETag:         `"v1"`,
+		LastModified: "Sun, 02 Apr 2023 08:15:00 GMT",
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Notifications.ListRepo(context.Background(), "octocat/hello-world", opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if res.Status != http.StatusNotModified {
+		t.Errorf("Want status 304, got %d", res.Status)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want no threads, got %d", len(got))
+	}
+}
+
+func TestNotificationFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/notifications/threads/5").
+		Reply(200).
+		Type("application/json").
+		File("testdata/notification.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Notifications.Find(context.Background(), 5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(structs.NotificationThread)
+	raw, _ := ioutil.ReadFile("testdata/notification.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestNotificationCountNew(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/notifications/new").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"new":3}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Notifications.CountNew(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if got != 3 {
+		t.Errorf("Want 3 new notifications, got %d", got)
+	}
+}
+
+func TestNotificationMark(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/notifications/threads/5").
+		MatchParam("to-status", "pinned").
+		Reply(205).
+		Type("application/json").
+		File("testdata/notification.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Notifications.Mark(context.Background(), 5, api.NotificationPinned)
+	if err != nil {
+		t.Error(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestNotificationMarkAll(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/notifications").
+		MatchParam("last_read_at", "2023-04-02T00:00:00Z").
+		MatchParam("status-types", "unread").
+		MatchParam("to-status", "read").
+		Reply(205).
+		Type("application/json").
+		File("testdata/notifications.json")
+
+	opts := api.NotificationMarkOptions{
+		LastReadAt: time.Date(2023, time.April, 2, 0, 0, 0, 0, time.UTC),
+		Status:     []string{api.NotificationUnread},
+		ToStatus:   api.NotificationRead,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Notifications.MarkAll(context.Background(), opts)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("Want 1 changed thread, got %d", len(got))
+	}
+}
+
+func TestNotificationMarkRepo(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/repos/octocat/hello-world/notifications").
+		MatchParam("all", "true").
+		MatchParam("to-status", "read").
+		Reply(205).
+		Type("application/json").
+		BodyString(`[]`)
+
+	opts := api.NotificationMarkOptions{
+		All:      true,
+		ToStatus: api.NotificationRead,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Notifications.MarkRepo(context.Background(), "octocat/hello-world", opts)
+	if err != nil {
+		t.Error(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
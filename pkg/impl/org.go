@@ -11,6 +11,7 @@ import (
 	"net/http"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type organizationService struct {
@@ -25,16 +26,68 @@ func (s *organizationService) Find(ctx context.Context, name string) (*api.Organ
 }
 
 func (s *organizationService) FindMembership(ctx context.Context, name, username string) (*api.Membership, *api.Response, error) {
-	return nil, nil, api.ErrNotSupported
+	out, res, err := s.FindPermissions(ctx, name, username)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertMembership(out), res, nil
+}
+
+func (s *organizationService) FindPermissions(ctx context.Context, name, username string) (*structs.OrganizationPermissions, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/users/%s/orgs/%s/permissions", username, name)
+	out := new(structs.OrganizationPermissions)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
 }
 
 func (s *organizationService) List(ctx context.Context, opts api.ListOptions) ([]*api.Organization, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/user/orgs?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*org{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertOrgList(out), res, err
 }
 
+func (s *organizationService) Create(ctx context.Context, in *structs.CreateOrgOption) (*structs.Organization, *api.Response, error) {
+	path := "api/v1/orgs"
+	out := new(structs.Organization)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *organizationService) Update(ctx context.Context, name string, in *structs.EditOrgOption) (*structs.Organization, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/orgs/%s", name)
+	out := new(structs.Organization)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return out, res, err
+}
+
+func (s *organizationService) Delete(ctx context.Context, name string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/orgs/%s", name)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *organizationService) ListMembers(ctx context.Context, name string, opts api.ListOptions) ([]*api.User, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/orgs/%s/members?%s", name, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*user{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertUserList(out), res, err
+}
+
+func (s *organizationService) ListPublicMembers(ctx context.Context, name string, opts api.ListOptions) ([]*api.User, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/orgs/%s/public_members?%s", name, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*user{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertUserList(out), res, err
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, name, username string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/orgs/%s/members/%s", name, username)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
 func (s *organizationService) CheckMember(ctx context.Context, org, username string) (bool, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/orgs/%s/members/%s", org, username)
 	res, err := s.client.do(ctx, "GET", path, nil, nil)
@@ -89,6 +142,22 @@ func convertMember(from member) *api.Member {
 	}
 }
 
+// convertMembership maps the organization permissions to a
+// membership. Users with read access are members of at least
+// one organization team; users without are not members.
+func convertMembership(from *structs.OrganizationPermissions) *api.Membership {
+	to := new(api.Membership)
+	switch {
+	case from.IsOwner, from.IsAdmin:
+		to.Active = true
+		to.Role = api.RoleAdmin
+	case from.CanRead:
+		to.Active = true
+		to.Role = api.RoleMember
+	}
+	return to
+}
+
 func convertOrgList(from []*org) []*api.Organization {
 	to := []*api.Organization{}
 	for _, v := range from {
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
 )
@@ -42,10 +43,199 @@ func TestOrgFind(t *testing.T) {
 }
 
 func TestOrganizationFindMembership(t *testing.T) {
+	tests := []struct {
+		perms string
+		want  *api.Membership
+	}{
+		{`{"is_owner":true,"is_admin":true,"can_write":true,"can_read":true}`, &api.Membership{Active: true, Role: api.RoleAdmin}},
+		{`{"is_admin":true,"can_write":true,"can_read":true}`, &api.Membership{Active: true, Role: api.RoleAdmin}},
+		{`{"can_write":true,"can_read":true}`, &api.Membership{Active: true, Role: api.RoleMember}},
+		{`{"can_read":true}`, &api.Membership{Active: true, Role: api.RoleMember}},
+		{`{}`, &api.Membership{Active: false, Role: api.RoleUndefined}},
+	}
+	for _, test := range tests {
+		gock.New("https://example.gitbundle.com").
+			Get("/api/v1/users/jcitizen/orgs/gogits/permissions").
+			Reply(200).
+			Type("application/json").
+			BodyString(test.perms)
+
+		client, _ := New("https://example.gitbundle.com")
+		got, _, err := client.Organizations.FindMembership(context.Background(), "gogits", "jcitizen")
+		if err != nil {
+			t.Error(err)
+		}
+		if diff := cmp.Diff(got, test.want); diff != "" {
+			t.Errorf("Unexpected Results for %s", test.perms)
+			t.Log(diff)
+		}
+		gock.Off()
+	}
+}
+
+func TestOrganizationFindPermissions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/users/jcitizen/orgs/gogits/permissions").
+		Reply(200).
+		Type("application/json").
+		File("testdata/org_permissions.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Organizations.FindPermissions(context.Background(), "gogits", "jcitizen")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(structs.OrganizationPermissions)
+	raw, _ := ioutil.ReadFile("testdata/org_permissions.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/orgs").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"username":                      "gogits",
+			"full_name":                     "gogits",
+			"description":                   "",
+			"website":                       "",
+			"location":                      "",
+			"visibility":                    "private",
+			"repo_admin_change_team_access": false,
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/organization.json")
+
+	in := &structs.CreateOrgOption{
+		UserName:   "gogits",
+		FullName:   "gogits",
+		Visibility: "private",
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Organizations.Create(context.Background(), in)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(structs.Organization)
+	raw, _ := ioutil.ReadFile("testdata/organization.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/orgs/gogits").
+		Reply(200).
+		Type("application/json").
+		File("testdata/organization.json")
+
 	client, _ := New("https://example.gitbundle.com")
-	_, _, err := client.Organizations.FindMembership(context.Background(), "gogits", "jcitizen")
-	if err != api.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.Organizations.Update(context.Background(), "gogits", &structs.EditOrgOption{FullName: "gogits"})
+	if err != nil {
+		t.Error(err)
+	}
+	if got.UserName != "gogits" {
+		t.Errorf("Want organization gogits, got %s", got.UserName)
+	}
+}
+
+func TestOrganizationDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/orgs/gogits").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Organizations.Delete(context.Background(), "gogits"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOrganizationListMembers(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/orgs/gogits/members").
+		MatchParam("page", "1").
+		MatchParam("limit", "50").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/org_members.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Organizations.ListMembers(context.Background(), "gogits", api.ListOptions{Page: 1, Size: 50})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*api.User{}
+	raw, _ := ioutil.ReadFile("testdata/org_members.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestOrganizationListPublicMembers(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/orgs/gogits/public_members").
+		Reply(200).
+		Type("application/json").
+		File("testdata/org_members.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Organizations.ListPublicMembers(context.Background(), "gogits", api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.User{}
+	raw, _ := ioutil.ReadFile("testdata/org_members.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationRemoveMember(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/orgs/gogits/members/jcitizen").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Organizations.RemoveMember(context.Background(), "gogits", "jcitizen"); err != nil {
+		t.Error(err)
 	}
 }
 
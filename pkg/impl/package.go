@@ -0,0 +1,52 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type packageService struct {
+	client *wrapper
+}
+
+func (s *packageService) List(ctx context.Context, owner string, opts api.PackageListOptions) ([]*structs.Package, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/packages/%s?%s", owner, encodePackageListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.Package{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *packageService) Find(ctx context.Context, owner, kind, name, version string) (*structs.Package, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/packages/%s", packagePath(owner, kind, name, version))
+	out := new(structs.Package)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *packageService) ListFiles(ctx context.Context, owner, kind, name, version string) ([]*structs.PackageFile, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/packages/%s/files", packagePath(owner, kind, name, version))
+	out := []*structs.PackageFile{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *packageService) Delete(ctx context.Context, owner, kind, name, version string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/packages/%s", packagePath(owner, kind, name, version))
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// packagePath returns the escaped path of a package version.
+// Package names may contain slashes, such as scoped npm
+// packages and container images.
+func packagePath(owner, kind, name, version string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", owner, kind, url.PathEscape(name), url.PathEscape(version))
+}
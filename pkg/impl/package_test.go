@@ -0,0 +1,125 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestPackageList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/packages/octocat").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		MatchParam("type", "npm").
+		MatchParam("q", "hello-world").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/packages.json")
+
+	opts := api.PackageListOptions{Page: 1, Size: 30, Type: "npm", Query: "hello-world"}
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Packages.List(context.Background(), "octocat", opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.Package{}
+	raw, _ := ioutil.ReadFile("testdata/packages.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestPackageFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/packages/octocat/npm/hello-world/1.2.0").
+		Reply(200).
+		Type("application/json").
+		File("testdata/package.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Packages.Find(context.Background(), "octocat", "npm", "hello-world", "1.2.0")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(structs.Package)
+	raw, _ := ioutil.ReadFile("testdata/package.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPackageListFiles(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/packages/octocat/npm/hello-world/1.2.0/files").
+		Reply(200).
+		Type("application/json").
+		File("testdata/package_files.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Packages.ListFiles(context.Background(), "octocat", "npm", "hello-world", "1.2.0")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.PackageFile{}
+	raw, _ := ioutil.ReadFile("testdata/package_files.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPackageDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/packages/octocat/container/hello-world/1.0.0").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, err := client.Packages.Delete(context.Background(), "octocat", "container", "hello-world", "1.0.0")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPackagePath(t *testing.T) {
+	got := packagePath("octocat", "npm", "@octocat/hello-world", "1.2.0+build.1")
+	want := "octocat/npm/@octocat%2Fhello-world/1.2.0+build.1"
+	if got != want {
+		t.Errorf("Want package path %s, got %s", want, got)
+	}
+}
@@ -8,6 +8,7 @@ package impl
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	api "github.com/gitbundle/api"
@@ -29,14 +30,33 @@ func (s *pullService) FindComment(context.Context, string, int, int) (*api.Comme
 }
 
 func (s *pullService) List(ctx context.Context, repo string, opts api.PullRequestListOptions) ([]*api.PullRequest, *api.Response, error) {
-	path := fmt.Sprintf("api/v1/repos/%s/pulls?%s", repo, encodePullRequestListOptions(opts))
-	out := []*pr{}
+	// the pull request endpoint does not support the issue
+	// filters, so pull requests are listed with the issue
+	// endpoint and then fetched by number.
+	path := fmt.Sprintf("api/v1/repos/%s/issues?%s", repo, encodePullRequestListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*issueRef{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertPullRequests(out), res, err
+	if err != nil {
+		return nil, res, err
+	}
+	prs := make([]*api.PullRequest, len(out))
+	err = each(ctx, len(out), func(ctx context.Context, i int) error {
+		pr, _, err := s.Find(ctx, repo, out[i].Number)
+		prs[i] = pr
+		return err
+	})
+	if err != nil {
+		return nil, res, err
+	}
+	return prs, res, nil
 }
 
-func (s *pullService) ListComments(context.Context, string, int, api.ListOptions) ([]*api.Comment, *api.Response, error) {
-	return nil, nil, api.ErrNotSupported
+// pull request comments are issue comments, and are
+// managed through the issue comment endpoints.
+
+func (s *pullService) ListComments(ctx context.Context, repo string, index int, opts api.ListOptions) ([]*api.Comment, *api.Response, error) {
+	return s.issues().ListComments(ctx, repo, index, opts)
 }
 
 func (s *pullService) ListCommits(context.Context, string, int, api.ListOptions) ([]*api.Commit, *api.Response, error) {
@@ -60,12 +80,38 @@ func (s *pullService) Create(ctx context.Context, repo string, input *api.PullRe
 	return convertPullRequest(out), res, err
 }
 
-func (s *pullService) CreateComment(context.Context, string, int, *api.CommentInput) (*api.Comment, *api.Response, error) {
-	return nil, nil, api.ErrNotSupported
+func (s *pullService) Update(ctx context.Context, repo string, index int, input *api.PullRequestUpdateInput) (*api.PullRequest, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d", repo, index)
+	in := &prUpdateInput{
+		Title: input.Title,
+		Body:  input.Body,
+		Base:  input.Target,
+	}
+	out := new(pr)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertPullRequest(out), res, err
 }
 
-func (s *pullService) DeleteComment(context.Context, string, int, int) (*api.Response, error) {
-	return nil, api.ErrNotSupported
+func (s *pullService) CreateComment(ctx context.Context, repo string, index int, input *api.CommentInput) (*api.Comment, *api.Response, error) {
+	return s.issues().CreateComment(ctx, repo, index, input)
+}
+
+func (s *pullService) UpdateComment(ctx context.Context, repo string, index, id int, input *api.CommentInput) (*api.Comment, *api.Response, error) {
+	return s.issues().UpdateComment(ctx, repo, index, id, input)
+}
+
+func (s *pullService) DeleteComment(ctx context.Context, repo string, index, id int) (*api.Response, error) {
+	return s.issues().DeleteComment(ctx, repo, index, id)
+}
+
+func (s *pullService) Diff(ctx context.Context, repo string, index int) (io.ReadCloser, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d.diff", repo, index)
+	return s.client.stream(ctx, "GET", path, nil)
+}
+
+func (s *pullService) Patch(ctx context.Context, repo string, index int) (io.ReadCloser, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d.patch", repo, index)
+	return s.client.stream(ctx, "GET", path, nil)
 }
 
 func (s *pullService) Merge(ctx context.Context, repo string, index int) (*api.Response, error) {
@@ -78,6 +124,10 @@ func (s *pullService) Close(context.Context, string, int) (*api.Response, error)
 	return nil, api.ErrNotSupported
 }
 
+func (s *pullService) issues() *issueService {
+	return &issueService{s.client}
+}
+
 //
 // native data structures
 //
@@ -99,6 +149,7 @@ type pr struct {
 	DiffURL    string     `json:"diff_url"`
 	Mergeable  bool       `json:"mergeable"`
 	Merged     bool       `json:"merged"`
+	Reactions  reactions  `json:"reactions"`
 	Created    time.Time  `json:"created_at"`
 	Updated    time.Time  `json:"updated_at"`
 	Labels     []struct {
@@ -120,6 +171,20 @@ type prInput struct {
 	Base  string `json:"base"`
 }
 
+// issueRef is the pull request number of an issue list
+// item.
+type issueRef struct {
+	Number int `json:"number"`
+}
+
+// prUpdateInput only encodes the fields that are set, so
+// that unset fields are left unchanged by the server.
+type prUpdateInput struct {
+	Title *string `json:"title,omitempty"`
+	Body  *string `json:"body,omitempty"`
+	Base  *string `json:"base,omitempty"`
+}
+
 //
 // native data structure conversion
 //
@@ -141,34 +206,36 @@ func convertPullRequest(src *pr) *api.PullRequest {
 		})
 	}
 	return &api.PullRequest{
-		Number:  src.Number,
-		Title:   src.Title,
-		Body:    src.Body,
-		Sha:     src.Head.Sha,
-		Source:  src.Head.Name,
-		Target:  src.Base.Name,
-		Link:    src.HTMLURL,
-		Diff:    src.DiffURL,
-		Fork:    src.Base.Repo.FullName,
-		Ref:     fmt.Sprintf("refs/pull/%d/head", src.Number),
-		Closed:  src.State == "closed",
-		Author:  *convertUser(&src.User),
-		Merged:  src.Merged,
-		Created: src.Created,
-		Updated: src.Updated,
-		Labels:  labels,
+		Number:    src.Number,
+		Title:     src.Title,
+		Body:      src.Body,
+		Sha:       src.Head.Sha,
+		Source:    src.Head.Name,
+		Target:    src.Base.Name,
+		Link:      src.HTMLURL,
+		Diff:      src.DiffURL,
+		Fork:      src.Base.Repo.FullName,
+		Ref:       fmt.Sprintf("refs/pull/%d/head", src.Number),
+		Closed:    src.State == "closed",
+		Author:    *convertUser(&src.User),
+		Merged:    src.Merged,
+		Created:   src.Created,
+		Updated:   src.Updated,
+		Labels:    labels,
+		Reactions: convertReactions(src.Reactions),
 	}
 }
 
 func convertPullRequestFromIssue(src *issue) *api.PullRequest {
 	return &api.PullRequest{
-		Number:  src.Number,
-		Title:   src.Title,
-		Body:    src.Body,
-		Closed:  src.State == "closed",
-		Author:  *convertUser(&src.User),
-		Merged:  src.PullRequest.Merged,
-		Created: src.Created,
-		Updated: src.Updated,
+		Number:    src.Number,
+		Title:     src.Title,
+		Body:      src.Body,
+		Closed:    src.State == "closed",
+		Author:    *convertUser(&src.User),
+		Merged:    src.PullRequest.Merged,
+		Created:   src.Created,
+		Updated:   src.Updated,
+		Reactions: convertReactions(src.Reactions),
 	}
 }
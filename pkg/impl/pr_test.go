@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	api "github.com/gitbundle/api"
 	"github.com/google/go-cmp/cmp"
@@ -49,15 +50,24 @@ func TestPullRequestList(t *testing.T) {
 	defer gock.Off()
 
 	gock.New("https://example.gitbundle.com").
-		Get("/api/v1/repos/jcitizen/my-repo/pulls").
+		Get("/api/v1/repos/jcitizen/my-repo/issues").
+		MatchParam("type", "pulls").
 		Reply(200).
 		Type("application/json").
-		File("testdata/prs.json")
+		SetHeaders(mockPageHeaders).
+		BodyString(`[{"number":1,"pull_request":{"merged":false}}]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
 
 	client, _ := New("https://example.gitbundle.com")
-	got, _, err := client.PullRequests.List(context.Background(), "jcitizen/my-repo", api.PullRequestListOptions{})
+	got, res, err := client.PullRequests.List(context.Background(), "jcitizen/my-repo", api.PullRequestListOptions{})
 	if err != nil {
 		t.Error(err)
+		return
 	}
 
 	want := []*api.PullRequest{}
@@ -68,6 +78,93 @@ func TestPullRequestList(t *testing.T) {
 		t.Errorf("Unexpected Results")
 		t.Log(diff)
 	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestPullRequestList_Filters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/jcitizen/my-repo/issues").
+		MatchParam("type", "pulls").
+		MatchParam("state", "closed").
+		MatchParam("labels", "bug,ui").
+		MatchParam("milestones", "v1.0").
+		MatchParam("assigned_by", "octocat").
+		MatchParam("created_by", "jcitizen").
+		MatchParam("mentioned_by", "janedoe").
+		MatchParam("since", "2023-03-01T00:00:00Z").
+		MatchParam("before", "2023-04-01T00:00:00Z").
+		MatchParam("q", "license").
+		MatchParam("sort", "recentupdate").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"number":1},{"number":2}]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"number":1,"title":"Add License File"}`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/2").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"number":2,"title":"Update License File"}`)
+
+	opts := api.PullRequestListOptions{
+		Closed:     true,
+		Labels:     []string{"bug", "ui"},
+		Milestones: []string{"v1.0"},
+		Assignee:   "octocat",
+		Author:     "jcitizen",
+		Mentioned:  "janedoe",
+		Since:      time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Before:     time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+		Keyword:    "license",
+		Sort:       api.SortRecentUpdate,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.PullRequests.List(context.Background(), "jcitizen/my-repo", opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// the pull requests are returned in the list order.
+	titles := []string{}
+	for _, pr := range got {
+		titles = append(titles, pr.Title)
+	}
+	if diff := cmp.Diff(titles, []string{"Add License File", "Update License File"}); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestPullRequestList_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/jcitizen/my-repo/issues").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"number":1}]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"not found"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.PullRequests.List(context.Background(), "jcitizen/my-repo", api.PullRequestListOptions{}); err == nil {
+		t.Errorf("Expect error fetching the pull request")
+	}
 }
 
 func TestPullRequestCreate(t *testing.T) {
@@ -125,6 +222,56 @@ func TestPullRequestMerge(t *testing.T) {
 	}
 }
 
+func TestPullRequestDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/pulls/1.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/commit.diff")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.PullRequests.Diff(context.Background(), "go-magit/magit", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	got, _ := ioutil.ReadAll(rc)
+	want, _ := ioutil.ReadFile("testdata/commit.diff")
+	if diff := cmp.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestPatch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/pulls/1.patch").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/commit.patch")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.PullRequests.Patch(context.Background(), "go-magit/magit", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	got, _ := ioutil.ReadAll(rc)
+	want, _ := ioutil.ReadFile("testdata/commit.patch")
+	if diff := cmp.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 //
 // pull request change sub-tests
 //
@@ -150,26 +297,182 @@ func TestPullRequestCommentFind(t *testing.T) {
 }
 
 func TestPullRequestCommentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/comments.json")
+
 	client, _ := New("https://example.gitbundle.com")
-	_, _, err := client.PullRequests.ListComments(context.Background(), "go-magit/magit", 1, api.ListOptions{})
-	if err != api.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.PullRequests.ListComments(context.Background(), "go-magit/magit", 1, api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestPullRequestCommentCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/issues/1/comments").
+		MatchType("json").
+		JSON(map[string]string{"body": "what?"}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/comment.json")
+
 	client, _ := New("https://example.gitbundle.com")
-	_, _, err := client.PullRequests.CreateComment(context.Background(), "go-magit/magit", 1, &api.CommentInput{})
-	if err != api.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.PullRequests.CreateComment(context.Background(), "go-magit/magit", 1, &api.CommentInput{Body: "what?"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestCommentUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/issues/comments/74").
+		MatchType("json").
+		JSON(map[string]string{"body": "what?"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/comment.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.PullRequests.UpdateComment(context.Background(), "go-magit/magit", 1, 74, &api.CommentInput{Body: "what?"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestPullRequestCommentDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/issues/1/comments/1").
+		Reply(204)
+
 	client, _ := New("https://example.gitbundle.com")
-	_, err := client.PullRequests.DeleteComment(context.Background(), "go-magit/magit", 1, 1)
-	if err != api.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	if _, err := client.PullRequests.DeleteComment(context.Background(), "go-magit/magit", 1, 1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullRequestUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/pulls/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"title": "Add License File",
+			"body":  "Using a BSD License",
+			"base":  "master",
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	title, body, target := "Add License File", "Using a BSD License", "master"
+	input := &api.PullRequestUpdateInput{
+		Title:  &title,
+		Body:   &body,
+		Target: &target,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.PullRequests.Update(context.Background(), "go-magit/magit", 1, input)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestUpdate_ClearBody(t *testing.T) {
+	defer gock.Off()
+
+	// the title and target are left unchanged and the body
+	// is cleared.
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/pulls/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"body": "",
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	body := ""
+	input := &api.PullRequestUpdateInput{Body: &body}
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.PullRequests.Update(context.Background(), "go-magit/magit", 1, input); err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestPullRequestUpdate_Title(t *testing.T) {
+	defer gock.Off()
+
+	// the body and target are left unchanged.
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/pulls/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"title": "Add License File",
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	title := "Add License File"
+	input := &api.PullRequestUpdateInput{Title: &title}
+	client, _ := New("https://example.gitbundle.com")
+	if _, _, err := client.PullRequests.Update(context.Background(), "go-magit/magit", 1, input); err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
 	}
 }
 
@@ -4,9 +4,11 @@ package impl
 import (
 	"context"
 	"fmt"
+	"io"
 
 	api "github.com/gitbundle/api"
 	"github.com/gitbundle/api/internal/null"
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type releaseService struct {
@@ -32,6 +34,7 @@ func (s *releaseService) FindByTag(ctx context.Context, repo string, tag string)
 func (s *releaseService) List(ctx context.Context, repo string, opts api.ReleaseListOptions) ([]*api.Release, *api.Response, error) {
 	namespace, name := api.Split(repo)
 	path := fmt.Sprintf("api/v1/repos/%s/%s/releases?%s", namespace, name, encodeReleaseListOptions(releaseListOptionsToGiteaListOptions(opts)))
+	path = s.client.paginate(path, opts.URL)
 	out := []*release{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertReleaseList(out), res, err
@@ -89,6 +92,36 @@ func (s *releaseService) UpdateByTag(ctx context.Context, repo string, tag strin
 	return s.Update(ctx, repo, rel.ID, input)
 }
 
+func (s *releaseService) ListAssets(ctx context.Context, repo string, id int, opts api.ListOptions) ([]*structs.Attachment, *api.Response, error) {
+	return s.assets(repo, id).list(ctx, opts)
+}
+
+func (s *releaseService) FindAsset(ctx context.Context, repo string, id int, asset int64) (*structs.Attachment, *api.Response, error) {
+	return s.assets(repo, id).find(ctx, asset)
+}
+
+func (s *releaseService) UploadAsset(ctx context.Context, repo string, id int, name string, r io.Reader) (*structs.Attachment, *api.Response, error) {
+	return s.assets(repo, id).upload(ctx, name, r)
+}
+
+func (s *releaseService) UpdateAsset(ctx context.Context, repo string, id int, asset int64, in *structs.EditAttachmentOptions) (*structs.Attachment, *api.Response, error) {
+	return s.assets(repo, id).update(ctx, asset, in)
+}
+
+func (s *releaseService) DeleteAsset(ctx context.Context, repo string, id int, asset int64) (*api.Response, error) {
+	return s.assets(repo, id).delete(ctx, asset)
+}
+
+func (s *releaseService) DownloadAsset(ctx context.Context, repo string, id int, asset int64) (io.ReadCloser, *api.Response, error) {
+	return s.assets(repo, id).download(ctx, asset)
+}
+
+func (s *releaseService) assets(repo string, id int) attachments {
+	namespace, name := api.Split(repo)
+	path := fmt.Sprintf("api/v1/repos/%s/%s/releases/%d/assets", namespace, name, id)
+	return attachments{s.client, path}
+}
+
 type ReleaseInput struct {
 	TagName      string `json:"tag_name"`
 	Target       string `json:"target_commitish"`
@@ -117,16 +150,6 @@ type release struct {
 	Attachments  []*Attachment `json:"assets"`
 }
 
-type Attachment struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Size          int64     `json:"size"`
-	DownloadCount int64     `json:"download_count"`
-	Created       null.Time `json:"created_at"`
-	UUID          string    `json:"uuid"`
-	DownloadURL   string    `json:"browser_download_url"`
-}
-
 func convertRelease(src *release) *api.Release {
 	return &api.Release{
 		ID:          int(src.ID),
@@ -139,6 +162,7 @@ func convertRelease(src *release) *api.Release {
 		Prerelease:  src.IsPrerelease,
 		Created:     src.CreatedAt.ValueOrZero(),
 		Published:   src.PublishedAt.ValueOrZero(),
+		Assets:      convertAttachmentList(src.Attachments),
 	}
 }
 
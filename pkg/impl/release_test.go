@@ -5,9 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
 )
@@ -350,3 +353,110 @@ func TestReleaseDeleteByTag(t *testing.T) {
 	}
 
 }
+
+func TestReleaseListAssets(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/octocat/hello-world/releases/1/assets").
+		Reply(200).
+		Type("application/json").
+		File("testdata/release_assets.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Releases.ListAssets(context.Background(), "octocat/hello-world", 1, api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*structs.Attachment{}
+	raw, _ := ioutil.ReadFile("testdata/release_assets.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReleaseUploadAsset(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/octocat/hello-world/releases/1/assets").
+		MatchParam("name", "app-linux-amd64.tar.gz").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			file, header, err := req.FormFile("attachment")
+			if err != nil {
+				return false, err
+			}
+			defer file.Close()
+			data, _ := ioutil.ReadAll(file)
+			return header.Filename == "app-linux-amd64.tar.gz" && string(data) == "binary", nil
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/release_asset.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Releases.UploadAsset(context.Background(), "octocat/hello-world", 1, "app-linux-amd64.tar.gz", strings.NewReader("binary"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(structs.Attachment)
+	raw, _ := ioutil.ReadFile("testdata/release_asset.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReleaseDownloadAsset(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/octocat/hello-world/releases/1/assets/3").
+		Reply(200).
+		Type("application/json").
+		File("testdata/release_asset.json")
+
+	gock.New("https://example.gitbundle.com").
+		Get("/attachments/7d4f4b45-4e6f-4ab1-9a53-0f1d5e4c9b1e").
+		Reply(200).
+		Type("application/octet-stream").
+		BodyString("binary")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.Releases.DownloadAsset(context.Background(), "octocat/hello-world", 1, 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, _ := ioutil.ReadAll(rc)
+	if got, want := string(data), "binary"; got != want {
+		t.Errorf("Want asset content %q, got %q", want, got)
+	}
+}
+
+func TestReleaseDownloadAssetNotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/octocat/hello-world/releases/1/assets/3").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"attachment not found"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Releases.DownloadAsset(context.Background(), "octocat/hello-world", 1, 3)
+	if err == nil || err.Error() != "attachment not found" {
+		t.Errorf("Want attachment not found error, got %v", err)
+	}
+}
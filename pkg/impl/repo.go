@@ -8,6 +8,7 @@ package impl
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -35,6 +36,11 @@ func (s *repositoryService) FindHook(ctx context.Context, repo string, id string
 	return convertHook(out), res, err
 }
 
+func (s *repositoryService) Archive(ctx context.Context, repo, ref, format string) (io.ReadCloser, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/archive/%s.%s", repo, api.TrimRef(ref), format)
+	return s.client.stream(ctx, "GET", path, nil)
+}
+
 func (s *repositoryService) FindPerms(ctx context.Context, repo string) (*api.Perm, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s", repo)
 	out := new(repository)
@@ -44,6 +50,7 @@ func (s *repositoryService) FindPerms(ctx context.Context, repo string) (*api.Pe
 
 func (s *repositoryService) List(ctx context.Context, opts api.ListOptions) ([]*api.Repository, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/user/repos?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*repository{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertRepositoryList(out), res, err
@@ -51,6 +58,7 @@ func (s *repositoryService) List(ctx context.Context, opts api.ListOptions) ([]*
 
 func (s *repositoryService) ListHooks(ctx context.Context, repo string, opts api.ListOptions) ([]*api.Hook, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/hooks?%s", repo, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*hook{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertHookList(out), res, err
@@ -58,6 +66,7 @@ func (s *repositoryService) ListHooks(ctx context.Context, repo string, opts api
 
 func (s *repositoryService) ListStatus(ctx context.Context, repo string, ref string, opts api.ListOptions) ([]*api.Status, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/statuses/%s?%s", repo, ref, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
 	out := []*status{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertStatusList(out), res, err
@@ -144,6 +153,28 @@ func (s *repositoryService) CheckCollaboratorPermission(ctx context.Context, rep
 	return out, res, err
 }
 
+func (s *repositoryService) ListCollaborators(ctx context.Context, repo string, opts api.ListOptions) ([]*api.User, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/collaborators?%s", repo, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*user{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertUserList(out), res, err
+}
+
+func (s *repositoryService) AddCollaborator(ctx context.Context, repo, collaborator, permission string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/collaborators/%s", repo, collaborator)
+	in := new(structs.AddCollaboratorOption)
+	if permission != "" {
+		in.Permission = &permission
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+func (s *repositoryService) RemoveCollaborator(ctx context.Context, repo, collaborator string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/collaborators/%s", repo, collaborator)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
 func (s *repositoryService) ListTeams(ctx context.Context, repo string) ([]*api.Team, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/teams", repo)
 	out := []*team{}
@@ -151,6 +182,89 @@ func (s *repositoryService) ListTeams(ctx context.Context, repo string) ([]*api.
 	return convertTeamList(out), res, err
 }
 
+func (s *repositoryService) ListDeployKeys(ctx context.Context, repo string, opts api.ListOptions) ([]*structs.DeployKey, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/keys?%s", repo, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.DeployKey{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *repositoryService) FindDeployKey(ctx context.Context, repo string, id int64) (*structs.DeployKey, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/keys/%d", repo, id)
+	out := new(structs.DeployKey)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *repositoryService) CreateDeployKey(ctx context.Context, repo string, in *structs.CreateKeyOption) (*structs.DeployKey, *api.Response, error) {
+	key, err := api.ParsePublicKey(in.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	// reject the key if a deploy key with the same
+	// fingerprint already exists.
+	opts := api.ListOptions{Size: 50}
+	for {
+		keys, res, err := s.ListDeployKeys(ctx, repo, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, k := range keys {
+			if k.Fingerprint == key.Fingerprint {
+				return nil, res, api.ErrDuplicateKey
+			}
+		}
+		opts.Page = res.Page.Next
+		opts.URL = res.Page.NextURL
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/keys", repo)
+	out := new(structs.DeployKey)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *repositoryService) DeleteDeployKey(ctx context.Context, repo string, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/keys/%d", repo, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *repositoryService) ListGitHooks(ctx context.Context, repo string) (structs.GitHookList, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/hooks/git", repo)
+	out := structs.GitHookList{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *repositoryService) FindGitHook(ctx context.Context, repo, name string) (*structs.GitHook, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/hooks/git/%s", repo, name)
+	out := new(structs.GitHook)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *repositoryService) UpdateGitHook(ctx context.Context, repo, name string, in *structs.EditGitHookOption) (*structs.GitHook, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/hooks/git/%s", repo, name)
+	out := new(structs.GitHook)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return out, res, err
+}
+
+func (s *repositoryService) DeleteGitHook(ctx context.Context, repo, name string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/hooks/git/%s", repo, name)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *repositoryService) ListIssueTemplates(ctx context.Context, repo string) ([]*api.IssueTemplate, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issue_templates", repo)
+	out := []*issueTemplate{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertIssueTemplateList(out), res, err
+}
+
 func (s *repositoryService) FindRequires(ctx context.Context, repo string) (*structs.Requirement, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/requires", repo)
 	out := new(structs.Requirement)
@@ -167,6 +281,7 @@ func (s *repositoryService) ListClusters(ctx context.Context, repo string, opt a
 
 func (s *repositoryService) ListSimplePullRequests(ctx context.Context, repo string, opt api.ListOptions) ([]*structs.SimplePullRequest, *api.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/simple-pull-requests?%s", repo, encodeListOptions(opt))
+	path = s.client.paginate(path, opt.URL)
 	out := make([]*structs.SimplePullRequest, 0, 8)
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return out, res, err
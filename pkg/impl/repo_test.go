@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
 )
@@ -45,6 +46,29 @@ func TestRepoFind(t *testing.T) {
 	}
 }
 
+func TestRepoArchive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/octocat/hello-world/archive/main.tar.gz").
+		Reply(200).
+		Type("application/octet-stream").
+		BodyString("archive")
+
+	client, _ := New("https://example.gitbundle.com")
+	rc, _, err := client.Repositories.Archive(context.Background(), "octocat/hello-world", "refs/heads/main", api.ArchiveTarGz)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, _ := ioutil.ReadAll(rc)
+	if got, want := string(data), "archive"; got != want {
+		t.Errorf("Want archive content %q, got %q", want, got)
+	}
+}
+
 func TestRepoFindPerm(t *testing.T) {
 	defer gock.Off()
 
@@ -341,3 +365,264 @@ func TestStatusCreate(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+//
+// collaborator sub-tests
+//
+
+func TestCollaboratorList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/collaborators").
+		Reply(200).
+		Type("application/json").
+		File("testdata/collaborators.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Repositories.ListCollaborators(context.Background(), "go-magit/magit", api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.User{}
+	raw, _ := ioutil.ReadFile("testdata/collaborators.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestCollaboratorAdd(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/repos/go-magit/magit/collaborators/jcitizen").
+		MatchType("json").
+		JSON(map[string]string{"permission": "read"}).
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, err := client.Repositories.AddCollaborator(context.Background(), "go-magit/magit", "jcitizen", "read")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollaboratorRemove(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/collaborators/jcitizen").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, err := client.Repositories.RemoveCollaborator(context.Background(), "go-magit/magit", "jcitizen")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+//
+// deploy key sub-tests
+//
+
+func TestDeployKeyList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/keys").
+		Reply(200).
+		Type("application/json").
+		File("testdata/deploy_keys.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Repositories.ListDeployKeys(context.Background(), "go-magit/magit", api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.DeployKey{}
+	raw, _ := ioutil.ReadFile("testdata/deploy_keys.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestDeployKeyCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/keys").
+		MatchParam("limit", "50").
+		Reply(200).
+		Type("application/json").
+		BodyString("[]")
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/keys").
+		Reply(201).
+		Type("application/json").
+		File("testdata/deploy_key.json")
+
+	in := &structs.CreateKeyOption{
+		Title:    "ci",
+		Key:      "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIce0Hdcrc3wYIwaEUl9qO7Ut0MDhG4BzQ23mhgPu69H ci@example.com",
+		ReadOnly: true,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Repositories.CreateDeployKey(context.Background(), "go-magit/magit", in)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(structs.DeployKey)
+	raw, _ := ioutil.ReadFile("testdata/deploy_key.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestDeployKeyCreate_Invalid(t *testing.T) {
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Repositories.CreateDeployKey(context.Background(), "go-magit/magit", &structs.CreateKeyOption{Key: "invalid"})
+	if err != api.ErrInvalidKey {
+		t.Errorf("Expect invalid key error, got %v", err)
+	}
+}
+
+func TestDeployKeyCreate_Duplicate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/keys").
+		Reply(200).
+		Type("application/json").
+		File("testdata/deploy_keys.json")
+
+	in := &structs.CreateKeyOption{
+		Title:    "cd",
+		Key:      "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIce0Hdcrc3wYIwaEUl9qO7Ut0MDhG4BzQ23mhgPu69H cd@example.com",
+		ReadOnly: true,
+	}
+	client, _ := New("https://example.gitbundle.com")
+	_, _, err := client.Repositories.CreateDeployKey(context.Background(), "go-magit/magit", in)
+	if err != api.ErrDuplicateKey {
+		t.Errorf("Expect duplicate key error, got %v", err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestDeployKeyDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/keys/1").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, err := client.Repositories.DeleteDeployKey(context.Background(), "go-magit/magit", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+//
+// git hook sub-tests
+//
+
+func TestGitHookList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/hooks/git").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"name":"pre-receive","is_active":true,"content":"#!/bin/sh\nexit 0\n"},{"name":"update","is_active":false}]`)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Repositories.ListGitHooks(context.Background(), "go-magit/magit")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := structs.GitHookList{
+		{Name: "pre-receive", IsActive: true, Content: "#!/bin/sh\nexit 0\n"},
+		{Name: "update"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/hooks/git/pre-receive").
+		MatchType("json").
+		JSON(&structs.EditGitHookOption{Content: "#!/bin/sh\nexit 0\n"}).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"name":"pre-receive","is_active":true,"content":"#!/bin/sh\nexit 0\n"}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Repositories.UpdateGitHook(context.Background(), "go-magit/magit", "pre-receive", &structs.EditGitHookOption{Content: "#!/bin/sh\nexit 0\n"})
+	if err != nil {
+		t.Error(err)
+	}
+	if !got.IsActive {
+		t.Errorf("Want git hook active")
+	}
+}
+
+func TestGitHookDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/hooks/git/pre-receive").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, err := client.Repositories.DeleteGitHook(context.Background(), "go-magit/magit", "pre-receive")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRepositoryListIssueTemplates(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issue_templates").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_templates.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Repositories.ListIssueTemplates(context.Background(), "go-magit/magit")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*api.IssueTemplate{}
+	raw, _ := ioutil.ReadFile("testdata/issue_templates.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type teamService struct {
+	client *wrapper
+}
+
+func (s *teamService) List(ctx context.Context, org string, opts api.ListOptions) ([]*api.Team, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/orgs/%s/teams?%s", org, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*team{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertTeamList(out), res, err
+}
+
+func (s *teamService) Search(ctx context.Context, org, query string, description bool, opts api.ListOptions) ([]*api.Team, *api.Response, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	if description {
+		params.Set("include_desc", "true")
+	}
+	path := fmt.Sprintf("api/v1/orgs/%s/teams/search?%s", org, params.Encode())
+	if query := encodeListOptions(opts); query != "" {
+		path = path + "&" + query
+	}
+	path = s.client.paginate(path, opts.URL)
+	out := new(teamSearchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertTeamList(out.Data), res, err
+}
+
+func (s *teamService) Find(ctx context.Context, id int64) (*api.Team, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d", id)
+	out := new(team)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertTeam(out), res, err
+}
+
+func (s *teamService) Create(ctx context.Context, org string, in *structs.CreateTeamOption) (*api.Team, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/orgs/%s/teams", org)
+	out := new(team)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertTeam(out), res, err
+}
+
+func (s *teamService) Update(ctx context.Context, id int64, in *structs.EditTeamOption) (*api.Team, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d", id)
+	out := new(team)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertTeam(out), res, err
+}
+
+func (s *teamService) Delete(ctx context.Context, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d", id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *teamService) ListMembers(ctx context.Context, id int64, opts api.ListOptions) ([]*api.User, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d/members?%s", id, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*user{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertUserList(out), res, err
+}
+
+func (s *teamService) AddMember(ctx context.Context, id int64, username string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d/members/%s", id, username)
+	return s.client.do(ctx, "PUT", path, nil, nil)
+}
+
+func (s *teamService) RemoveMember(ctx context.Context, id int64, username string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d/members/%s", id, username)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *teamService) ListRepos(ctx context.Context, id int64, opts api.ListOptions) ([]*api.Repository, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d/repos?%s", id, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*repository{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertRepositoryList(out), res, err
+}
+
+func (s *teamService) AddRepo(ctx context.Context, id int64, repo string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d/repos/%s", id, repo)
+	return s.client.do(ctx, "PUT", path, nil, nil)
+}
+
+func (s *teamService) RemoveRepo(ctx context.Context, id int64, repo string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/teams/%d/repos/%s", id, repo)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+//
+// native data structures
+//
+
+type teamSearchResults struct {
+	OK   bool    `json:"ok"`
+	Data []*team `json:"data"`
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestTeamList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/orgs/gogits/teams").
+		Reply(200).
+		Type("application/json").
+		File("testdata/teams.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Teams.List(context.Background(), "gogits", api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.Team{}
+	raw, _ := ioutil.ReadFile("testdata/teams.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestTeamSearch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/orgs/gogits/teams/search").
+		MatchParam("q", "dev").
+		MatchParam("include_desc", "true").
+		MatchParam("limit", "10").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"ok":true,"data":[{"id":2,"name":"developers"}]}`)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Teams.Search(context.Background(), "gogits", "dev", true, api.ListOptions{Size: 10})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 1 || got[0].Name != "developers" {
+		t.Errorf("Unexpected Results")
+	}
+}
+
+func TestTeamFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/teams/2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/team.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Teams.Find(context.Background(), 2)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.Team)
+	raw, _ := ioutil.ReadFile("testdata/team.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestTeamCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/orgs/gogits/teams").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"name":                      "developers",
+			"description":               "Product developers",
+			"includes_all_repositories": false,
+			"permission":                "write",
+			"units":                     nil,
+			"units_map":                 map[string]string{"repo.code": "write"},
+			"can_create_org_repo":       false,
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/team.json")
+
+	in := &structs.CreateTeamOption{
+		Name:        "developers",
+		Description: "Product developers",
+		Permission:  "write",
+		UnitsMap:    map[string]string{"repo.code": "write"},
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Teams.Create(context.Background(), "gogits", in)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.Team)
+	raw, _ := ioutil.ReadFile("testdata/team.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestTeamUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/teams/2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/team.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Teams.Update(context.Background(), 2, &structs.EditTeamOption{Name: "developers", Permission: "write"})
+	if err != nil {
+		t.Error(err)
+	}
+	if got.ID != 2 {
+		t.Errorf("Want team 2, got %d", got.ID)
+	}
+}
+
+func TestTeamDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/teams/2").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Teams.Delete(context.Background(), 2); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTeamMembers(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/teams/2/members").
+		Reply(200).
+		Type("application/json").
+		File("testdata/org_members.json")
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/teams/2/members/jcitizen").
+		Reply(204)
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/teams/2/members/jcitizen").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Teams.ListMembers(context.Background(), 2, api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.User{}
+	raw, _ := ioutil.ReadFile("testdata/org_members.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if _, err := client.Teams.AddMember(context.Background(), 2, "jcitizen"); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Teams.RemoveMember(context.Background(), 2, "jcitizen"); err != nil {
+		t.Error(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestTeamRepos(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/teams/2/repos").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repos.json")
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/teams/2/repos/gogits/gogs").
+		Reply(204)
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/teams/2/repos/gogits/gogs").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Teams.ListRepos(context.Background(), 2, api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*api.Repository{}
+	raw, _ := ioutil.ReadFile("testdata/repos.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if _, err := client.Teams.AddRepo(context.Background(), 2, "gogits/gogs"); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Teams.RemoveRepo(context.Background(), 2, "gogits/gogs"); err != nil {
+		t.Error(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"strconv"
+	"strings"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+//
+// native data structure conversion
+//
+
+func convertTimeline(from []*structs.TimelineComment) []*api.TimelineEvent {
+	to := []*api.TimelineEvent{}
+	for _, v := range from {
+		to = append(to, convertTimelineComment(v))
+	}
+	return to
+}
+
+func convertTimelineComment(from *structs.TimelineComment) *api.TimelineEvent {
+	to := &api.TimelineEvent{
+		ID:       from.ID,
+		Kind:     api.ParseTimelineEventKind(from.Type),
+		Body:     from.Body,
+		Link:     from.HTMLURL,
+		Label:    from.Label,
+		RefIssue: from.RefIssue,
+		RefSHA:   from.RefCommitSHA,
+		ReviewID: from.ReviewID,
+		Created:  from.Created,
+		Updated:  from.Updated,
+	}
+	if from.Poster != nil {
+		to.Author = *convertStructsUser(from.Poster)
+	}
+	if from.Assignee != nil {
+		to.Assignee = convertStructsUser(from.Assignee)
+	}
+
+	switch to.Kind {
+	case api.TimelineClose:
+		to.Old, to.New = "open", "closed"
+	case api.TimelineReopen:
+		to.Old, to.New = "closed", "open"
+	case api.TimelineMerge:
+		to.Old, to.New = "open", "merged"
+	case api.TimelineLock:
+		to.Old, to.New = "unlocked", "locked"
+	case api.TimelineUnlock:
+		to.Old, to.New = "locked", "unlocked"
+	case api.TimelineLabel:
+		// the server reports an added label with a body
+		// of "1" and a removed label with an empty body.
+		if from.Label != nil {
+			if from.Body == "1" {
+				to.New = from.Label.Name
+			} else {
+				to.Kind = api.TimelineUnlabel
+				to.Old = from.Label.Name
+			}
+		}
+	case api.TimelineAssign:
+		if to.Assignee != nil {
+			if from.RemovedAssignee {
+				to.Kind = api.TimelineUnassign
+				to.Old = to.Assignee.Login
+			} else {
+				to.New = to.Assignee.Login
+			}
+		}
+	case api.TimelineMilestone:
+		if from.OldMilestone != nil {
+			to.Old = from.OldMilestone.Title
+		}
+		if from.Milestone != nil {
+			to.New = from.Milestone.Title
+		}
+	case api.TimelineChangeTitle:
+		to.Old, to.New = from.OldTitle, from.NewTitle
+	case api.TimelineChangeTargetBranch, api.TimelineChangeIssueRef:
+		to.Old, to.New = from.OldRef, from.NewRef
+	case api.TimelineDeleteBranch:
+		to.Old = from.OldRef
+	case api.TimelineProject, api.TimelineProjectBoard:
+		if from.OldProjectID != 0 {
+			to.Old = strconv.FormatInt(from.OldProjectID, 10)
+		}
+		if from.ProjectID != 0 {
+			to.New = strconv.FormatInt(from.ProjectID, 10)
+		}
+	case api.TimelineAddDeadline:
+		to.New = from.Body
+	case api.TimelineRemoveDeadline:
+		to.Old = from.Body
+	case api.TimelineModifyDeadline:
+		// the server reports a modified deadline as the
+		// new and old deadline separated by a pipe.
+		if parts := strings.SplitN(from.Body, "|", 2); len(parts) == 2 {
+			to.New, to.Old = parts[0], parts[1]
+		}
+	case api.TimelineCommitRef:
+		to.New = from.RefCommitSHA
+	case api.TimelineIssueRef, api.TimelinePullRef, api.TimelineCommentRef:
+		if from.RefIssue != nil {
+			to.New = from.RefIssue.HTMLURL
+		}
+	}
+	return to
+}
+
+func convertStructsUser(from *structs.User) *api.User {
+	return &api.User{
+		ID:          strconv.FormatInt(from.ID, 10),
+		Login:       from.UserName,
+		Name:        from.FullName,
+		Email:       from.Email,
+		Avatar:      from.AvatarURL,
+		Location:    from.Location,
+		Website:     from.Website,
+		Description: from.Description,
+		Language:    from.Language,
+		Visibility:  from.Visibility,
+		IsAdmin:     from.IsAdmin,
+		Restricted:  from.Restricted,
+		Active:      from.IsActive,
+		LastLogin:   from.LastLogin,
+		Created:     from.Created,
+	}
+}
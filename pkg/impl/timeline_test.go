@@ -0,0 +1,77 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	api "github.com/gitbundle/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestIssueListTimeline(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/1/timeline").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		MatchParam("since", "2023-03-01T00:00:00Z").
+		Reply(200).
+		Type("application/json").
+		SetHeader("Link", `<https://example.gitbundle.com/api/v1/repos/go-magit/magit/issues/1/timeline?page=2&limit=30>; rel="next"`).
+		File("testdata/timeline.json")
+
+	since := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	client, _ := New("https://example.gitbundle.com")
+	got, res, err := client.Issues.ListTimeline(context.Background(), "go-magit/magit", 1, since, api.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*api.TimelineEvent{}
+	raw, _ := ioutil.ReadFile("testdata/timeline.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.Next, 2; got != want {
+		t.Errorf("Want next page %d, got %d", want, got)
+	}
+}
+
+func TestTimelineEventKind(t *testing.T) {
+	tests := []struct {
+		kind api.TimelineEventKind
+		text string
+	}{
+		{api.TimelineLabel, "label"},
+		{api.TimelineUnlabel, "unlabel"},
+		{api.TimelineAssign, "assignees"},
+		{api.TimelineUnassign, "unassign"},
+		{api.TimelineReview, "review"},
+		{api.TimelineUnknown, "unknown"},
+	}
+	for _, test := range tests {
+		raw, _ := json.Marshal(test.kind)
+		var kind api.TimelineEventKind
+		json.Unmarshal(raw, &kind)
+		if kind != test.kind {
+			t.Errorf("Want kind %s, got %s", test.kind, kind)
+		}
+		if got := test.kind.String(); got != test.text {
+			t.Errorf("Want text %s, got %s", test.text, got)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type timeService struct {
+	client *wrapper
+}
+
+func (s *timeService) ListIssue(ctx context.Context, repo string, number int, opts api.TrackedTimeListOptions) (structs.TrackedTimeList, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/times?%s", repo, number, encodeTrackedTimeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := structs.TrackedTimeList{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *timeService) ListRepo(ctx context.Context, repo string, opts api.TrackedTimeListOptions) (structs.TrackedTimeList, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/times?%s", repo, encodeTrackedTimeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := structs.TrackedTimeList{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *timeService) ListUser(ctx context.Context, opts api.TrackedTimeListOptions) (structs.TrackedTimeList, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/times?%s", encodeTrackedTimeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := structs.TrackedTimeList{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *timeService) Add(ctx context.Context, repo string, number int, in *structs.AddTimeOption) (*structs.TrackedTime, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/times", repo, number)
+	out := new(structs.TrackedTime)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *timeService) Delete(ctx context.Context, repo string, number int, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/times/%d", repo, number, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *timeService) Reset(ctx context.Context, repo string, number int) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/times", repo, number)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *timeService) StartStopwatch(ctx context.Context, repo string, number int) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/stopwatch/start", repo, number)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func (s *timeService) StopStopwatch(ctx context.Context, repo string, number int) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/stopwatch/stop", repo, number)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func (s *timeService) CancelStopwatch(ctx context.Context, repo string, number int) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/stopwatch/delete", repo, number)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *timeService) ListStopwatches(ctx context.Context, opts api.ListOptions) (structs.StopWatches, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/stopwatches?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := structs.StopWatches{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestTimeListIssue(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/1/times").
+		MatchParam("user", "janedoe").
+		MatchParam("since", "2023-03-01T00:00:00Z").
+		MatchParam("before", "2023-04-01T00:00:00Z").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tracked_times.json")
+
+	opts := api.TrackedTimeListOptions{
+		User:   "janedoe",
+		Since:  time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Times.ListIssue(context.Background(), "go-magit/magit", 1, opts)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := structs.TrackedTimeList{}
+	raw, _ := ioutil.ReadFile("testdata/tracked_times.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestTimeListRepo(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/times").
+		MatchParam("page", "2").
+		MatchParam("limit", "50").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tracked_times.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Times.ListRepo(context.Background(), "go-magit/magit", api.TrackedTimeListOptions{Page: 2, Size: 50})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 1 || got[0].Issue.Milestone.Title != "v1.0" {
+		t.Errorf("Unexpected Results")
+	}
+}
+
+func TestTimeListUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/user/times").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tracked_times.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Times.ListUser(context.Background(), api.TrackedTimeListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("Unexpected Results")
+	}
+}
+
+func TestTimeAdd(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/issues/1/times").
+		MatchType("json").
+		JSON(map[string]interface{}{"time": 1800, "created": "0001-01-01T00:00:00Z", "user_name": ""}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/tracked_time.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Times.Add(context.Background(), "go-magit/magit", 1, &structs.AddTimeOption{Time: 1800})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(structs.TrackedTime)
+	raw, _ := ioutil.ReadFile("testdata/tracked_time.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestTimeDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/issues/1/times/2").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Times.Delete(context.Background(), "go-magit/magit", 1, 2); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTimeReset(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/issues/1/times$").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Times.Reset(context.Background(), "go-magit/magit", 1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStopwatch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/issues/1/stopwatch/start").
+		Reply(201)
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/issues/1/stopwatch/stop").
+		Reply(201)
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/issues/1/stopwatch/delete").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	if _, err := client.Times.StartStopwatch(context.Background(), "go-magit/magit", 1); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Times.StopStopwatch(context.Background(), "go-magit/magit", 1); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Times.CancelStopwatch(context.Background(), "go-magit/magit", 1); err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected all stopwatch requests to be sent")
+	}
+}
+
+func TestStopwatchList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/user/stopwatches").
+		Reply(200).
+		Type("application/json").
+		File("testdata/stopwatches.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Times.ListStopwatches(context.Background(), api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := structs.StopWatches{}
+	raw, _ := ioutil.ReadFile("testdata/stopwatches.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -6,11 +6,15 @@
 package impl
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type userService struct {
@@ -35,8 +39,174 @@ func (s *userService) FindEmail(ctx context.Context) (string, *api.Response, err
 	return user.Email, res, err
 }
 
-func (s *userService) ListEmail(context.Context, api.ListOptions) ([]*api.Email, *api.Response, error) {
-	return nil, nil, api.ErrNotSupported
+func (s *userService) ListEmail(ctx context.Context, opts api.ListOptions) ([]*api.Email, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/emails?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.Email{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertEmailList(out), res, err
+}
+
+func (s *userService) CreateEmail(ctx context.Context, in *structs.CreateEmailOption) ([]*api.Email, *api.Response, error) {
+	out := []*structs.Email{}
+	res, err := s.client.do(ctx, "POST", "api/v1/user/emails", in, &out)
+	return convertEmailList(out), res, err
+}
+
+func (s *userService) DeleteEmail(ctx context.Context, in *structs.DeleteEmailOption) (*api.Response, error) {
+	return s.client.do(ctx, "DELETE", "api/v1/user/emails", in, nil)
+}
+
+func (s *userService) FindSettings(ctx context.Context) (*structs.UserSettings, *api.Response, error) {
+	out := new(structs.UserSettings)
+	res, err := s.client.do(ctx, "GET", "api/v1/user/settings", nil, out)
+	return out, res, err
+}
+
+func (s *userService) UpdateSettings(ctx context.Context, in *structs.UserSettingsOptions) (*structs.UserSettings, *api.Response, error) {
+	out := new(structs.UserSettings)
+	res, err := s.client.do(ctx, "PATCH", "api/v1/user/settings", in, out)
+	return out, res, err
+}
+
+func (s *userService) ListKeys(ctx context.Context, opts api.ListOptions) ([]*structs.PublicKey, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/keys?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.PublicKey{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *userService) FindKey(ctx context.Context, id int64) (*structs.PublicKey, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/keys/%d", id)
+	out := new(structs.PublicKey)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *userService) CreateKey(ctx context.Context, in *structs.CreateKeyOption) (*structs.PublicKey, *api.Response, error) {
+	key, err := api.ParsePublicKey(in.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	// reject the key if the user already has a key with
+	// the same fingerprint.
+	opts := api.ListOptions{Size: 50}
+	for {
+		keys, res, err := s.ListKeys(ctx, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, k := range keys {
+			if k.Fingerprint == key.Fingerprint {
+				return nil, res, api.ErrDuplicateKey
+			}
+		}
+		opts.Page = res.Page.Next
+		opts.URL = res.Page.NextURL
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	out := new(structs.PublicKey)
+	res, err := s.client.do(ctx, "POST", "api/v1/user/keys", in, out)
+	return out, res, err
+}
+
+func (s *userService) DeleteKey(ctx context.Context, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/keys/%d", id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *userService) ListGPGKeys(ctx context.Context, opts api.ListOptions) ([]*structs.GPGKey, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/gpg_keys?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.GPGKey{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *userService) FindGPGKey(ctx context.Context, id int64) (*structs.GPGKey, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/gpg_keys/%d", id)
+	out := new(structs.GPGKey)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *userService) CreateGPGKey(ctx context.Context, in *structs.CreateGPGKeyOption) (*structs.GPGKey, *api.Response, error) {
+	out := new(structs.GPGKey)
+	res, err := s.client.do(ctx, "POST", "api/v1/user/gpg_keys", in, out)
+	return out, res, err
+}
+
+func (s *userService) DeleteGPGKey(ctx context.Context, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/gpg_keys/%d", id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *userService) FindGPGKeyToken(ctx context.Context) (string, *api.Response, error) {
+	out := new(bytes.Buffer)
+	res, err := s.client.do(ctx, "GET", "api/v1/user/gpg_key_token", nil, out)
+	return strings.TrimSpace(out.String()), res, err
+}
+
+func (s *userService) VerifyGPGKey(ctx context.Context, in *structs.VerifyGPGKeyOption) (*structs.GPGKey, *api.Response, error) {
+	out := new(structs.GPGKey)
+	res, err := s.client.do(ctx, "POST", "api/v1/user/gpg_key_verify", in, out)
+	return out, res, err
+}
+
+func (s *userService) ListTokens(ctx context.Context, login string, opts api.ListOptions) ([]*structs.AccessToken, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/users/%s/tokens?%s", login, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.AccessToken{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *userService) CreateToken(ctx context.Context, login string, in *structs.CreateAccessTokenOption) (*structs.AccessToken, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/users/%s/tokens", login)
+	out := new(structs.AccessToken)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return out, res, err
+}
+
+func (s *userService) DeleteToken(ctx context.Context, login string, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/users/%s/tokens/%d", login, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *userService) ListOAuth2Apps(ctx context.Context, opts api.ListOptions) ([]*structs.OAuth2Application, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/applications/oauth2?%s", encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.OAuth2Application{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *userService) FindOAuth2App(ctx context.Context, id int64) (*structs.OAuth2Application, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/applications/oauth2/%d", id)
+	out := new(structs.OAuth2Application)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (s *userService) CreateOAuth2App(ctx context.Context, in *structs.CreateOAuth2ApplicationOptions) (*structs.OAuth2Application, *api.Response, error) {
+	out := new(structs.OAuth2Application)
+	res, err := s.client.do(ctx, "POST", "api/v1/user/applications/oauth2", in, out)
+	return out, res, err
+}
+
+func (s *userService) UpdateOAuth2App(ctx context.Context, id int64, in *structs.CreateOAuth2ApplicationOptions) (*structs.OAuth2Application, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/applications/oauth2/%d", id)
+	out := new(structs.OAuth2Application)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return out, res, err
+}
+
+func (s *userService) DeleteOAuth2App(ctx context.Context, id int64) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/user/applications/oauth2/%d", id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 //
@@ -44,28 +214,66 @@ func (s *userService) ListEmail(context.Context, api.ListOptions) ([]*api.Email,
 //
 
 type user struct {
-	ID       int    `json:"id"`
-	Login    string `json:"login"`
-	Username string `json:"username"`
-	Fullname string `json:"full_name"`
-	Email    string `json:"email"`
-	Avatar   string `json:"avatar_url"`
-	IsAdmin  bool   `json:"is_admin"`
+	ID          int       `json:"id"`
+	Login       string    `json:"login"`
+	Username    string    `json:"username"`
+	Fullname    string    `json:"full_name"`
+	Email       string    `json:"email"`
+	Avatar      string    `json:"avatar_url"`
+	Location    string    `json:"location"`
+	Website     string    `json:"website"`
+	Description string    `json:"description"`
+	Language    string    `json:"language"`
+	Visibility  string    `json:"visibility"`
+	IsAdmin     bool      `json:"is_admin"`
+	Restricted  bool      `json:"restricted"`
+	Active      bool      `json:"active"`
+	LastLogin   time.Time `json:"last_login"`
+	Created     time.Time `json:"created"`
 }
 
 //
 // native data structure conversion
 //
 
+func convertUserList(src []*user) []*api.User {
+	dst := []*api.User{}
+	for _, v := range src {
+		dst = append(dst, convertUser(v))
+	}
+	return dst
+}
+
 func convertUser(src *user) *api.User {
 	return &api.User{
-		ID:      strconv.Itoa(src.ID),
-		Login:   userLogin(src),
-		Avatar:  src.Avatar,
-		Email:   src.Email,
-		Name:    src.Fullname,
-		IsAdmin: src.IsAdmin,
+		ID:          strconv.Itoa(src.ID),
+		Login:       userLogin(src),
+		Avatar:      src.Avatar,
+		Email:       src.Email,
+		Name:        src.Fullname,
+		Location:    src.Location,
+		Website:     src.Website,
+		Description: src.Description,
+		Language:    src.Language,
+		Visibility:  src.Visibility,
+		IsAdmin:     src.IsAdmin,
+		Restricted:  src.Restricted,
+		Active:      src.Active,
+		LastLogin:   src.LastLogin,
+		Created:     src.Created,
+	}
+}
+
+func convertEmailList(src []*structs.Email) []*api.Email {
+	dst := []*api.Email{}
+	for _, v := range src {
+		dst = append(dst, &api.Email{
+			Value:    v.Email,
+			Primary:  v.Primary,
+			Verified: v.Verified,
+		})
 	}
+	return dst
 }
 
 func userLogin(src *user) string {
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	api "github.com/gitbundle/api"
 )
@@ -25,6 +26,40 @@ func encodeListOptions(opts api.ListOptions) string {
 	return params.Encode()
 }
 
+func encodeCommitListOptions(opts api.CommitListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if opts.Ref != "" {
+		params.Set("sha", opts.Ref)
+	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+	if opts.Author != "" {
+		params.Set("author", opts.Author)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(api.SearchTimeFormat))
+	}
+	if !opts.Until.IsZero() {
+		params.Set("until", opts.Until.UTC().Format(api.SearchTimeFormat))
+	}
+	// stats and files are only requested when the options
+	// are set.
+	if opts.Stats {
+		params.Set("stat", "true")
+	}
+	if opts.Files {
+		params.Set("files", "true")
+	}
+	return params.Encode()
+}
+
 func encodeIssueListOptions(opts api.IssueListOptions) string {
 	params := url.Values{}
 	if opts.Page != 0 {
@@ -38,10 +73,40 @@ func encodeIssueListOptions(opts api.IssueListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	if len(opts.Labels) != 0 {
+		params.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	if len(opts.Milestones) != 0 {
+		params.Set("milestones", strings.Join(opts.Milestones, ","))
+	}
+	if opts.Assignee != "" {
+		params.Set("assigned_by", opts.Assignee)
+	}
+	if opts.Author != "" {
+		params.Set("created_by", opts.Author)
+	}
+	if opts.Mentioned != "" {
+		params.Set("mentioned_by", opts.Mentioned)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(api.SearchTimeFormat))
+	}
+	if !opts.Before.IsZero() {
+		params.Set("before", opts.Before.UTC().Format(api.SearchTimeFormat))
+	}
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	if opts.Keyword != "" {
+		params.Set("q", opts.Keyword)
+	}
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
 	return params.Encode()
 }
 
-func encodePullRequestListOptions(opts api.PullRequestListOptions) string {
+func encodeIssueSearchOptions(opts api.IssueSearchOptions) string {
 	params := url.Values{}
 	if opts.Page != 0 {
 		params.Set("page", strconv.Itoa(opts.Page))
@@ -54,9 +119,190 @@ func encodePullRequestListOptions(opts api.PullRequestListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	if len(opts.Labels) != 0 {
+		params.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	if len(opts.Milestones) != 0 {
+		params.Set("milestones", strings.Join(opts.Milestones, ","))
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(api.SearchTimeFormat))
+	}
+	if !opts.Before.IsZero() {
+		params.Set("before", opts.Before.UTC().Format(api.SearchTimeFormat))
+	}
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	if opts.Keyword != "" {
+		params.Set("q", opts.Keyword)
+	}
+	if opts.Owner != "" {
+		params.Set("owner", opts.Owner)
+	}
+	if opts.Team != "" {
+		params.Set("team", opts.Team)
+	}
+	if opts.Assigned {
+		params.Set("assigned", "true")
+	}
+	if opts.Created {
+		params.Set("created", "true")
+	}
+	if opts.Mentioned {
+		params.Set("mentioned", "true")
+	}
+	if opts.ReviewRequested {
+		params.Set("review_requested", "true")
+	}
 	return params.Encode()
 }
 
+// encodePullRequestListOptions encodes the options for the
+// issue list endpoint, limited to pull requests.
+func encodePullRequestListOptions(opts api.PullRequestListOptions) string {
+	return encodeIssueListOptions(api.IssueListOptions{
+		Page:       opts.Page,
+		Size:       opts.Size,
+		Open:       opts.Open,
+		Closed:     opts.Closed,
+		Labels:     opts.Labels,
+		Milestones: opts.Milestones,
+		Assignee:   opts.Assignee,
+		Author:     opts.Author,
+		Mentioned:  opts.Mentioned,
+		Since:      opts.Since,
+		Before:     opts.Before,
+		Type:       "pulls",
+		Keyword:    opts.Keyword,
+		Sort:       opts.Sort,
+	})
+}
+
+func encodeTimelineListOptions(since time.Time, opts api.ListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if !since.IsZero() {
+		params.Set("since", since.UTC().Format(api.SearchTimeFormat))
+	}
+	return params.Encode()
+}
+
+func encodeTrackedTimeListOptions(opts api.TrackedTimeListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if opts.User != "" {
+		params.Set("user", opts.User)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(api.SearchTimeFormat))
+	}
+	if !opts.Before.IsZero() {
+		params.Set("before", opts.Before.UTC().Format(api.SearchTimeFormat))
+	}
+	return params.Encode()
+}
+
+func encodeNotificationListOptions(opts api.NotificationListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if opts.All {
+		params.Set("all", "true")
+	}
+	for _, status := range opts.Status {
+		params.Add("status-types", status)
+	}
+	for _, kind := range opts.SubjectTypes {
+		params.Add("subject-type", strings.ToLower(string(kind)))
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(api.SearchTimeFormat))
+	}
+	if !opts.Before.IsZero() {
+		params.Set("before", opts.Before.UTC().Format(api.SearchTimeFormat))
+	}
+	return params.Encode()
+}
+
+func encodeNotificationMarkOptions(opts api.NotificationMarkOptions) string {
+	params := url.Values{}
+	if !opts.LastReadAt.IsZero() {
+		params.Set("last_read_at", opts.LastReadAt.UTC().Format(api.SearchTimeFormat))
+	}
+	if opts.All {
+		params.Set("all", "true")
+	}
+	for _, status := range opts.Status {
+		params.Add("status-types", status)
+	}
+	if opts.ToStatus != "" {
+		params.Set("to-status", opts.ToStatus)
+	}
+	return params.Encode()
+}
+
+func encodePackageListOptions(opts api.PackageListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	if opts.Query != "" {
+		params.Set("q", opts.Query)
+	}
+	return params.Encode()
+}
+
+func encodeLFSLockListOptions(opts api.LFSLockListOptions) string {
+	params := url.Values{}
+	if opts.Cursor != "" {
+		params.Set("cursor", opts.Cursor)
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+	if opts.ID != "" {
+		params.Set("id", opts.ID)
+	}
+	if opts.Refspec != "" {
+		params.Set("refspec", opts.Refspec)
+	}
+	return params.Encode()
+}
+
+// encodeRange returns the value of a Range header requesting
+// length bytes starting at offset, or every remaining byte if
+// length is not positive.
+func encodeRange(offset, length int64) string {
+	if length <= 0 {
+		return fmt.Sprintf("bytes=%d-", offset)
+	}
+	return fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+}
+
 // convertAPIURLToHTMLURL converts an release API endpoint into a html endpoint
 func convertAPIURLToHTMLURL(apiURL string, tagName string) string {
 	// "url": "https://try.magit.com/api/v1/repos/octocat/Hello-World/123",
@@ -81,7 +327,7 @@ func encodeMilestoneListOptions(opts api.MilestoneListOptions) string {
 		params.Set("page", strconv.Itoa(opts.Page))
 	}
 	if opts.Size != 0 {
-		params.Set("per_page", strconv.Itoa(opts.Size))
+		params.Set("limit", strconv.Itoa(opts.Size))
 	}
 	if opts.Open && opts.Closed {
 		params.Set("state", "all")
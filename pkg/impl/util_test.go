@@ -7,6 +7,7 @@ package impl
 
 import (
 	"testing"
+	"time"
 
 	api "github.com/gitbundle/api"
 )
@@ -23,6 +24,23 @@ func Test_encodeListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeCommitListOptions(t *testing.T) {
+	opts := api.CommitListOptions{
+		Page:   2,
+		Size:   30,
+		Ref:    "main",
+		Path:   "README.md",
+		Author: "jane.doe@example.com",
+		Since:  time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Stats:  true,
+	}
+	want := "author=jane.doe%40example.com&limit=30&page=2&path=README.md&sha=main&since=2023-09-01T00%3A00%3A00Z&stat=true"
+	got := encodeCommitListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded commit list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodeIssueListOptions(t *testing.T) {
 	opts := api.IssueListOptions{
 		Page:   10,
@@ -59,7 +77,7 @@ func Test_encodePullRequestListOptions(t *testing.T) {
 		Open:   true,
 		Closed: true,
 	}
-	want := "limit=30&page=10&state=all"
+	want := "limit=30&page=10&state=all&type=pulls"
 	got := encodePullRequestListOptions(opts)
 	if got != want {
 		t.Errorf("Want encoded pr list options %q, got %q", want, got)
@@ -74,7 +92,7 @@ func Test_encodePullRequestListOptions_Closed(t *testing.T) {
 		Open:   false,
 		Closed: true,
 	}
-	want := "limit=30&page=10&state=closed"
+	want := "limit=30&page=10&state=closed&type=pulls"
 	got := encodePullRequestListOptions(opts)
 	if got != want {
 		t.Errorf("Want encoded pr list options %q, got %q", want, got)
@@ -228,6 +228,7 @@ func convertPushHook(dst *pushHook) *api.PushHook {
 						Name:  c.Committer.Name,
 						Date:  c.Timestamp,
 					},
+					Verification: convertVerification(c.Verification),
 				})
 		}
 
@@ -250,6 +251,7 @@ func convertPushHook(dst *pushHook) *api.PushHook {
 					Name:  dst.Commits[0].Committer.Name,
 					Date:  dst.Commits[0].Timestamp,
 				},
+				Verification: convertVerification(dst.Commits[0].Verification),
 			},
 			Commits: commits,
 			Repo:    *convertRepository(&dst.Repository),
@@ -294,12 +296,13 @@ func convertPullRequestHook(dst *pullRequestHook) *api.PullRequestHook {
 			Merged: dst.PullRequest.Merged,
 			// Created: nil,
 			// Updated: nil,
-			Source: dst.PullRequest.Head.Name,
-			Target: dst.PullRequest.Base.Name,
-			Fork:   dst.PullRequest.Head.Repo.FullName,
-			Link:   dst.PullRequest.HTMLURL,
-			Ref:    fmt.Sprintf("refs/pull/%d/head", dst.PullRequest.Number),
-			Sha:    dst.PullRequest.Head.Sha,
+			Source:    dst.PullRequest.Head.Name,
+			Target:    dst.PullRequest.Base.Name,
+			Fork:      dst.PullRequest.Head.Repo.FullName,
+			Link:      dst.PullRequest.HTMLURL,
+			Ref:       fmt.Sprintf("refs/pull/%d/head", dst.PullRequest.Number),
+			Sha:       dst.PullRequest.Head.Sha,
+			Reactions: convertReactions(dst.PullRequest.Reactions),
 		},
 		Repo:   *convertRepository(&dst.Repository),
 		Sender: *convertUser(&dst.Sender),
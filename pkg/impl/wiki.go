@@ -0,0 +1,112 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type wikiService struct {
+	client *wrapper
+}
+
+func (s *wikiService) Find(ctx context.Context, repo, page string) (*api.WikiPage, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/wiki/page/%s", repo, url.PathEscape(page))
+	out := new(structs.WikiPage)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	wiki, err := convertWikiPage(out)
+	return wiki, res, err
+}
+
+func (s *wikiService) List(ctx context.Context, repo string, opts api.ListOptions) ([]*structs.WikiPageMetaData, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/wiki/pages?%s", repo, encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := []*structs.WikiPageMetaData{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (s *wikiService) ListRevisions(ctx context.Context, repo, page string, opts api.ListOptions) ([]*structs.WikiCommit, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/wiki/revisions/%s?%s", repo, url.PathEscape(page), encodeListOptions(opts))
+	path = s.client.paginate(path, opts.URL)
+	out := new(structs.WikiCommitList)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out.WikiCommits, res, err
+}
+
+func (s *wikiService) Create(ctx context.Context, repo string, input *api.WikiPageInput) (*api.WikiPage, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/wiki/new", repo)
+	out := new(structs.WikiPage)
+	res, err := s.client.do(ctx, "POST", path, convertWikiPageInput(input), out)
+	if err != nil {
+		return nil, res, err
+	}
+	wiki, err := convertWikiPage(out)
+	return wiki, res, err
+}
+
+func (s *wikiService) Update(ctx context.Context, repo, page string, input *api.WikiPageInput) (*api.WikiPage, *api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/wiki/page/%s", repo, url.PathEscape(page))
+	out := new(structs.WikiPage)
+	res, err := s.client.do(ctx, "PATCH", path, convertWikiPageInput(input), out)
+	if err != nil {
+		return nil, res, err
+	}
+	wiki, err := convertWikiPage(out)
+	return wiki, res, err
+}
+
+func (s *wikiService) Delete(ctx context.Context, repo, page string) (*api.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/wiki/page/%s", repo, url.PathEscape(page))
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+//
+// native data structure conversion
+//
+
+func convertWikiPageInput(from *api.WikiPageInput) *structs.CreateWikiPageOptions {
+	return &structs.CreateWikiPageOptions{
+		Title:         from.Title,
+		ContentBase64: base64.StdEncoding.EncodeToString(from.Content),
+		Message:       from.Message,
+	}
+}
+
+func convertWikiPage(from *structs.WikiPage) (*api.WikiPage, error) {
+	data, err := base64.StdEncoding.DecodeString(from.ContentBase64)
+	if err != nil {
+		return nil, err
+	}
+	// the sidebar and footer are base64 encoded, like the
+	// page content.
+	sidebar, err := base64.StdEncoding.DecodeString(from.Sidebar)
+	if err != nil {
+		return nil, err
+	}
+	footer, err := base64.StdEncoding.DecodeString(from.Footer)
+	if err != nil {
+		return nil, err
+	}
+	to := &api.WikiPage{
+		Content:     data,
+		CommitCount: from.CommitCount,
+		Sidebar:     string(sidebar),
+		Footer:      string(footer),
+	}
+	if from.WikiPageMetaData != nil {
+		to.WikiPageMetaData = *from.WikiPageMetaData
+	}
+	return to, nil
+}
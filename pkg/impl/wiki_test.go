@@ -0,0 +1,141 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestWikiFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/wiki/page/Runbook").
+		Reply(200).
+		Type("application/json").
+		File("testdata/wiki_page.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Wiki.Find(context.Background(), "go-magit/magit", "Runbook")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(api.WikiPage)
+	raw, _ := ioutil.ReadFile("testdata/wiki_page.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got, want := string(got.Content), "# Runbook\n"; got != want {
+		t.Errorf("Want decoded content %q, got %q", want, got)
+	}
+}
+
+func TestWikiList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/wiki/pages").
+		MatchParam("page", "2").
+		MatchParam("limit", "10").
+		Reply(200).
+		Type("application/json").
+		File("testdata/wiki_pages.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Wiki.List(context.Background(), "go-magit/magit", api.ListOptions{Page: 2, Size: 10})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*structs.WikiPageMetaData{}
+	raw, _ := ioutil.ReadFile("testdata/wiki_pages.json")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestWikiListRevisions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/wiki/revisions/Runbook").
+		Reply(200).
+		Type("application/json").
+		File("testdata/wiki_revisions.json")
+
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Wiki.ListRevisions(context.Background(), "go-magit/magit", "Runbook", api.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(structs.WikiCommitList)
+	raw, _ := ioutil.ReadFile("testdata/wiki_revisions.json")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want.WikiCommits); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestWikiCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/wiki/new").
+		MatchType("json").
+		JSON(&structs.CreateWikiPageOptions{
+			Title:         "Runbook",
+			ContentBase64: "IyBSdW5ib29rCg==",
+			Message:       "Add Runbook",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/wiki_page.json")
+
+	input := &api.WikiPageInput{
+		Title:   "Runbook",
+		Content: []byte("# Runbook\n"),
+		Message: "Add Runbook",
+	}
+	client, _ := New("https://example.gitbundle.com")
+	got, _, err := client.Wiki.Create(context.Background(), "go-magit/magit", input)
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := got.Title, "Runbook"; got != want {
+		t.Errorf("Want title %q, got %q", want, got)
+	}
+}
+
+func TestWikiDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/repos/go-magit/magit/wiki/page/Runbook").
+		Reply(204)
+
+	client, _ := New("https://example.gitbundle.com")
+	_, err := client.Wiki.Delete(context.Background(), "go-magit/magit", "Runbook")
+	if err != nil {
+		t.Error(err)
+	}
+}
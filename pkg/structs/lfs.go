@@ -0,0 +1,74 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"time"
+)
+
+// LFS batch operations.
+const (
+	LFSOperationDownload = "download"
+	LFSOperationUpload   = "upload"
+)
+
+// LFS transfer action names.
+const (
+	LFSActionDownload = "download"
+	LFSActionUpload   = "upload"
+	LFSActionVerify   = "verify"
+)
+
+// LFSPointer represent the object id and size of an object
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md#requests
+type LFSPointer struct {
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// LFSBatchRequest contains the objects to transfer
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md#requests
+type LFSBatchRequest struct {
+	Operation     string        `json:"operation"`
+	Transfers     []string      `json:"transfers,omitempty"`
+	Ref           *LFSLockRef   `json:"ref,omitempty"`
+	Objects       []*LFSPointer `json:"objects"`
+	HashAlgorithm string        `json:"hash_algo,omitempty"`
+}
+
+// LFSBatchResponse contains the transfer actions of the objects
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md#successful-responses
+type LFSBatchResponse struct {
+	Transfer      string               `json:"transfer,omitempty"`
+	Objects       []*LFSObjectResponse `json:"objects"`
+	HashAlgorithm string               `json:"hash_algo,omitempty"`
+}
+
+// LFSObjectResponse contains the transfer actions of an object,
+// or the error preventing its transfer
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md#successful-responses
+type LFSObjectResponse struct {
+	LFSPointer
+	Authenticated bool                  `json:"authenticated,omitempty"`
+	Actions       map[string]*LFSAction `json:"actions,omitempty"`
+	Error         *LFSObjectError       `json:"error,omitempty"`
+}
+
+// LFSAction represent a transfer action of an object
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md#successful-responses
+type LFSAction struct {
+	Href      string            `json:"href"`
+	Header    map[string]string `json:"header,omitempty"`
+	ExpiresIn int64             `json:"expires_in,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+}
+
+// LFSObjectError contains information on the error that
+// prevents the transfer of an object
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md#response-errors
+type LFSObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
@@ -27,7 +27,14 @@ type LFSLockOwner struct {
 // LFSLockRequest contains the path of the lock to create
 // https://github.com/git-lfs/git-lfs/blob/master/docs/api/locking.md#create-lock
 type LFSLockRequest struct {
-	Path string `json:"path"`
+	Path string      `json:"path"`
+	Ref  *LFSLockRef `json:"ref,omitempty"`
+}
+
+// LFSLockRef represent the reference a lock belongs to
+// https://github.com/git-lfs/git-lfs/blob/master/docs/api/locking.md#create-lock
+type LFSLockRef struct {
+	Name string `json:"name"`
 }
 
 // LFSLockResponse represent a lock created
@@ -0,0 +1,101 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+
+	api "github.com/gitbundle/api"
+)
+
+type (
+	// Access represents the access granted to a repository
+	// through direct collaboration and organization teams.
+	Access struct {
+		Repo          string
+		Collaborators []*CollaboratorAccess
+		Teams         []*api.Team
+	}
+
+	// CollaboratorAccess represents the effective permission
+	// of a single repository collaborator.
+	CollaboratorAccess struct {
+		User        *api.User
+		Permission  string
+		RoleName    string
+		IsRepoAdmin bool
+	}
+)
+
+// Collaborators returns the full repository collaborator list,
+// traversing and combining paginated responses if necessary.
+func Collaborators(ctx context.Context, client *api.Client, repo string) ([]*api.User, error) {
+	list := []*api.User{}
+	opts := api.ListOptions{Size: 100}
+	for {
+		result, meta, err := client.Repositories.ListCollaborators(ctx, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range result {
+			if src != nil {
+				list = append(list, src)
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return list, nil
+}
+
+// RepoAccess returns the access report for the repository,
+// combining every collaborator permission with the teams
+// that have access to the repository.
+func RepoAccess(ctx context.Context, client *api.Client, repo string) (*Access, error) {
+	users, err := Collaborators(ctx, client, repo)
+	if err != nil {
+		return nil, err
+	}
+	access := &Access{Repo: repo}
+	for _, user := range users {
+		perm, _, err := client.Repositories.CheckCollaboratorPermission(ctx, repo, user.Login)
+		if err != nil {
+			return nil, err
+		}
+		access.Collaborators = append(access.Collaborators, &CollaboratorAccess{
+			User:        user,
+			Permission:  perm.Permission,
+			RoleName:    perm.RoleName,
+			IsRepoAdmin: perm.IsRepoAdmin,
+		})
+	}
+	access.Teams, _, err = client.Repositories.ListTeams(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// AccessReport returns the access report for every repository
+// visible to the authenticated user.
+func AccessReport(ctx context.Context, client *api.Client) ([]*Access, error) {
+	repos, err := Repos(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	report := []*Access{}
+	for _, repo := range repos {
+		access, err := RepoAccess(ctx, client, api.Join(repo.Namespace, repo.Name))
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, access)
+	}
+	return report, nil
+}
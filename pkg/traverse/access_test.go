@@ -0,0 +1,66 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/h2non/gock"
+)
+
+func TestRepoAccess(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/collaborators").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"id":1,"login":"jcitizen"},{"id":2,"login":"octocat"}]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/collaborators/jcitizen/permission").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"permission":"admin","role_name":"owner","is_repo_admin":true}`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/collaborators/octocat/permission").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"permission":"read","role_name":"read"}`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/teams").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"id":3,"name":"owners","permission":"owner","units_map":{"repo.code":"admin"}}]`)
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.RepoAccess(context.Background(), client, "go-magit/magit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Collaborators) != 2 {
+		t.Fatalf("Want 2 collaborators, got %d", len(got.Collaborators))
+	}
+	if got, want := got.Collaborators[0].Permission, "admin"; got != want {
+		t.Errorf("Want permission %q, got %q", want, got)
+	}
+	if !got.Collaborators[0].IsRepoAdmin {
+		t.Errorf("Want collaborator is repo admin")
+	}
+	if got, want := got.Collaborators[1].User.Login, "octocat"; got != want {
+		t.Errorf("Want login %q, got %q", want, got)
+	}
+	if len(got.Teams) != 1 {
+		t.Fatalf("Want 1 team, got %d", len(got.Teams))
+	}
+	if got, want := got.Teams[0].UnitsMap.Code, "admin"; got != want {
+		t.Errorf("Want team code permission %q, got %q", want, got)
+	}
+}
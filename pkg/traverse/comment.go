@@ -0,0 +1,71 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	api "github.com/gitbundle/api"
+)
+
+// Comments returns the full issue or pull request comment
+// list, traversing and combining paginated responses if
+// necessary.
+func Comments(ctx context.Context, client *api.Client, repo string, number int) ([]*api.Comment, error) {
+	list := []*api.Comment{}
+	opts := api.ListOptions{Size: 100}
+	for {
+		result, meta, err := client.Issues.ListComments(ctx, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range result {
+			if src != nil {
+				list = append(list, src)
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return list, nil
+}
+
+// UpsertComment updates the comment identified by the marker
+// on an issue or pull request, or creates the comment if it
+// does not exist. The marker is embedded in the comment body
+// as a hidden html comment, and only comments authored by the
+// authenticated user are considered. The comment is left
+// unchanged if the body is already up to date.
+func UpsertComment(ctx context.Context, client *api.Client, repo string, number int, marker, body string) (*api.Comment, error) {
+	tag := fmt.Sprintf("<!-- %s -->", marker)
+	body = body + "\n\n" + tag
+
+	user, _, err := client.Users.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := Comments(ctx, client, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		if comment.Author.Login != user.Login || !strings.Contains(comment.Body, tag) {
+			continue
+		}
+		if comment.Body == body {
+			return comment, nil
+		}
+		comment, _, err = client.Issues.UpdateComment(ctx, repo, number, comment.ID, &api.CommentInput{Body: body})
+		return comment, err
+	}
+	comment, _, err := client.Issues.CreateComment(ctx, repo, number, &api.CommentInput{Body: body})
+	return comment, err
+}
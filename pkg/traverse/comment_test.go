@@ -0,0 +1,91 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/h2non/gock"
+)
+
+func TestUpsertComment_Update(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/user").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"id":2,"login":"ci-bot"}`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/1/comments").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[
+			{"id":10,"user":{"id":1,"login":"janedoe"},"body":"copied\n\n<!-- ci-status -->"},
+			{"id":11,"user":{"id":2,"login":"ci-bot"},"body":"pending\n\n<!-- ci-status -->"}
+		]`)
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/go-magit/magit/issues/comments/11").
+		MatchType("json").
+		JSON(map[string]string{"body": "passed\n\n<!-- ci-status -->"}).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"id":11,"user":{"id":2,"login":"ci-bot"},"body":"passed\n\n<!-- ci-status -->"}`)
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.UpsertComment(context.Background(), client, "go-magit/magit", 1, "ci-status", "passed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 11 {
+		t.Errorf("Want comment 11 updated, got %d", got.ID)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestUpsertComment_Create(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/user").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"id":2,"login":"ci-bot"}`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/issues/1/comments").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[
+			{"id":10,"user":{"id":1,"login":"janedoe"},"body":"copied\n\n<!-- ci-status -->"}
+		]`)
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/repos/go-magit/magit/issues/1/comments").
+		MatchType("json").
+		JSON(map[string]string{"body": "passed\n\n<!-- ci-status -->"}).
+		Reply(201).
+		Type("application/json").
+		BodyString(`{"id":12,"user":{"id":2,"login":"ci-bot"},"body":"passed\n\n<!-- ci-status -->"}`)
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.UpsertComment(context.Background(), client, "go-magit/magit", 1, "ci-status", "passed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 12 {
+		t.Errorf("Want comment 12 created, got %d", got.ID)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
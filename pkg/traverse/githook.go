@@ -0,0 +1,91 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// GitHookOutcome identifies the outcome of applying a git
+// hook script to a repository.
+type GitHookOutcome int
+
+// GitHookOutcome values.
+const (
+	GitHookUpdated GitHookOutcome = iota
+	GitHookUnchanged
+	GitHookFailed
+)
+
+// String returns the string representation of GitHookOutcome.
+func (o GitHookOutcome) String() string {
+	switch o {
+	case GitHookUpdated:
+		return "updated"
+	case GitHookUnchanged:
+		return "unchanged"
+	default:
+		return "failed"
+	}
+}
+
+// GitHookResult reports the outcome of applying a git hook
+// script to a single repository.
+type GitHookResult struct {
+	Repo    string
+	Outcome GitHookOutcome
+	Err     error
+}
+
+// ApplyGitHook applies the git hook script to every repository
+// visible to the authenticated user that matches the filter. A
+// nil filter matches all repositories. Repositories whose hook
+// content already matches the script are skipped. Failures are
+// reported per repository and do not stop the traversal.
+func ApplyGitHook(ctx context.Context, client *api.Client, name, content string, filter func(*api.Repository) bool) ([]*GitHookResult, error) {
+	repos, err := Repos(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	results := []*GitHookResult{}
+	for _, repo := range repos {
+		if filter != nil && !filter(repo) {
+			continue
+		}
+		slug := api.Join(repo.Namespace, repo.Name)
+		results = append(results, applyGitHook(ctx, client, slug, name, content))
+	}
+	return results, nil
+}
+
+func applyGitHook(ctx context.Context, client *api.Client, repo, name, content string) *GitHookResult {
+	result := &GitHookResult{Repo: repo}
+	hook, _, err := client.Repositories.FindGitHook(ctx, repo, name)
+	if err != nil {
+		result.Outcome, result.Err = GitHookFailed, err
+		return result
+	}
+	if normalizeScript(hook.Content) == normalizeScript(content) {
+		result.Outcome = GitHookUnchanged
+		return result
+	}
+	in := &structs.EditGitHookOption{Content: content}
+	if _, _, err := client.Repositories.UpdateGitHook(ctx, repo, name, in); err != nil {
+		result.Outcome, result.Err = GitHookFailed, err
+		return result
+	}
+	result.Outcome = GitHookUpdated
+	return result
+}
+
+// normalizeScript normalizes line endings and surrounding
+// whitespace so that equivalent scripts compare equal.
+func normalizeScript(s string) string {
+	return strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/h2non/gock"
+)
+
+func TestApplyGitHook(t *testing.T) {
+	defer gock.Off()
+
+	script := "#!/bin/sh\nscan-secrets\n"
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/user/repos").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[
+			{"id":1,"name":"api","owner":{"login":"platform"}},
+			{"id":2,"name":"web","owner":{"login":"platform"}},
+			{"id":3,"name":"sandbox","owner":{"login":"jcitizen"}}
+		]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/platform/api/hooks/git/pre-receive").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"name":"pre-receive","is_active":true,"content":"#!/bin/sh\r\nscan-secrets\r\n"}`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/platform/web/hooks/git/pre-receive").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"name":"pre-receive","is_active":false}`)
+
+	gock.New("https://example.gitbundle.com").
+		Patch("/api/v1/repos/platform/web/hooks/git/pre-receive").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"name":"pre-receive","is_active":true}`)
+
+	filter := func(repo *api.Repository) bool {
+		return strings.EqualFold(repo.Namespace, "platform")
+	}
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.ApplyGitHook(context.Background(), client, "pre-receive", script, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Want 2 results, got %d", len(got))
+	}
+	if got, want := got[0].Outcome, traverse.GitHookUnchanged; got != want {
+		t.Errorf("Want outcome %s for %s, got %s", want, "platform/api", got)
+	}
+	if got, want := got[1].Outcome, traverse.GitHookUpdated; got != want {
+		t.Errorf("Want outcome %s for %s, got %s", want, "platform/web", got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
@@ -0,0 +1,467 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// ErrLFSObjectMismatch is returned when the content of a Git
+// LFS object does not match its object id or size.
+var ErrLFSObjectMismatch = errors.New("lfs object content does not match its oid or size")
+
+// lfsBatchSize is the maximum number of objects requested in a
+// single batch request.
+const lfsBatchSize = 100
+
+// lfsExpiryMargin is the margin before the expiry of a transfer
+// action at which the action is renewed.
+const lfsExpiryMargin = 10 * time.Second
+
+// LFSTransferOptions provides options for transferring Git LFS
+// objects.
+type LFSTransferOptions struct {
+	// Ref is the reference the objects belong to.
+	Ref string
+
+	// Workers is the number of concurrent transfers. Defaults
+	// to 8.
+	Workers int
+
+	// Retries is the number of times a transfer is retried
+	// after a transient failure without making progress.
+	// Defaults to 3.
+	Retries int
+
+	// Backoff is the delay before the first retry, doubled for
+	// every subsequent retry. Defaults to 500 milliseconds.
+	Backoff time.Duration
+}
+
+// DownloadLFS downloads the objects of the repository with the
+// basic transfer adapter, passing the content of each object to
+// store. Objects are downloaded concurrently, so store must be
+// safe for concurrent use.
+//
+// The content is verified against the object id and size while
+// it is streamed. If it does not match, reading fails with
+// ErrLFSObjectMismatch at the end of the content, so store must
+// not retain content when reading fails. Interrupted downloads
+// are resumed from the offset reached, and expired actions are
+// renewed with a new batch request.
+func DownloadLFS(ctx context.Context, client *api.Client, repo string, objects []*structs.LFSPointer, store func(*structs.LFSPointer, io.Reader) error, opts LFSTransferOptions) error {
+	t := newLFSTransfer(client, repo, structs.LFSOperationDownload, opts)
+	return t.run(ctx, objects, func(ctx context.Context, obj *structs.LFSPointer) error {
+		d := &lfsDownload{ctx: ctx, t: t, obj: obj, hash: sha256.New()}
+		defer d.close()
+		return store(obj, d)
+	})
+}
+
+// UploadLFS uploads the objects to the repository with the basic
+// transfer adapter, reading the content of each object from
+// open. Objects that already exist on the server are skipped.
+// Objects are uploaded concurrently, so open must be safe for
+// concurrent use.
+//
+// The content is verified against the object id and size while
+// it is streamed, and the upload fails with ErrLFSObjectMismatch
+// if it does not match. Failed uploads are retried from the
+// start, opening the content again, and expired actions are
+// renewed with a new batch request.
+func UploadLFS(ctx context.Context, client *api.Client, repo string, objects []*structs.LFSPointer, open func(*structs.LFSPointer) (io.ReadCloser, error), opts LFSTransferOptions) error {
+	t := newLFSTransfer(client, repo, structs.LFSOperationUpload, opts)
+	return t.run(ctx, objects, func(ctx context.Context, obj *structs.LFSPointer) error {
+		return t.upload(ctx, obj, open)
+	})
+}
+
+// lfsTransfer holds the state of a batch transfer.
+type lfsTransfer struct {
+	client    *api.Client
+	repo      string
+	operation string
+	opts      LFSTransferOptions
+
+	mu      sync.Mutex
+	objects map[string]*lfsObject
+}
+
+// lfsObject holds the batch response of an object and the time
+// it was received, from which its actions expire.
+type lfsObject struct {
+	*structs.LFSObjectResponse
+	issued time.Time
+}
+
+func newLFSTransfer(client *api.Client, repo, operation string, opts LFSTransferOptions) *lfsTransfer {
+	if opts.Workers <= 0 {
+		opts.Workers = 8
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = 3
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 500 * time.Millisecond
+	}
+	return &lfsTransfer{
+		client:    client,
+		repo:      repo,
+		operation: operation,
+		opts:      opts,
+		objects:   map[string]*lfsObject{},
+	}
+}
+
+// run requests the transfer actions of the objects and runs fn
+// for every object with the configured number of workers. The
+// first error cancels the remaining transfers and is returned.
+func (t *lfsTransfer) run(ctx context.Context, objects []*structs.LFSPointer, fn func(context.Context, *structs.LFSPointer) error) error {
+	for i := 0; i < len(objects); i += lfsBatchSize {
+		end := i + lfsBatchSize
+		if end > len(objects) {
+			end = len(objects)
+		}
+		if err := t.batch(ctx, objects[i:end]); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	jobs := make(chan *structs.LFSPointer)
+	for i := 0; i < t.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for obj := range jobs {
+				if err := fn(ctx, obj); err != nil {
+					once.Do(func() {
+						first = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+feed:
+	for _, obj := range objects {
+		select {
+		case jobs <- obj:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if first != nil {
+		return first
+	}
+	return ctx.Err()
+}
+
+// batch requests the transfer actions of the objects, retrying
+// transient failures.
+func (t *lfsTransfer) batch(ctx context.Context, objects []*structs.LFSPointer) error {
+	in := &structs.LFSBatchRequest{
+		Operation:     t.operation,
+		Transfers:     []string{"basic"},
+		Objects:       objects,
+		HashAlgorithm: "sha256",
+	}
+	if t.opts.Ref != "" {
+		in.Ref = &structs.LFSLockRef{Name: t.opts.Ref}
+	}
+	for attempt := 0; ; attempt++ {
+		out, res, err := t.client.LFS.Batch(ctx, t.repo, in)
+		if err != nil {
+			if attempt < t.opts.Retries && t.wait(ctx, res, err, attempt) {
+				continue
+			}
+			return err
+		}
+		if out.Transfer != "" && out.Transfer != "basic" {
+			return fmt.Errorf("lfs transfer adapter %s is not supported", out.Transfer)
+		}
+		issued := time.Now()
+		t.mu.Lock()
+		for _, obj := range out.Objects {
+			t.objects[obj.Oid] = &lfsObject{obj, issued}
+		}
+		t.mu.Unlock()
+		return nil
+	}
+}
+
+// action returns the named transfer action of an object,
+// renewing the actions of the object if they expire. It
+// returns nil if the object has no such action.
+func (t *lfsTransfer) action(ctx context.Context, obj *structs.LFSPointer, name string) (*structs.LFSAction, error) {
+	t.mu.Lock()
+	state, ok := t.objects[obj.Oid]
+	t.mu.Unlock()
+
+	if !ok || state.expired(time.Now()) {
+		if err := t.batch(ctx, []*structs.LFSPointer{obj}); err != nil {
+			return nil, err
+		}
+		t.mu.Lock()
+		state, ok = t.objects[obj.Oid]
+		t.mu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("lfs object %s: missing from batch response", obj.Oid)
+		}
+	}
+	if state.Error != nil {
+		return nil, fmt.Errorf("lfs object %s: %s", obj.Oid, state.Error.Message)
+	}
+	return state.Actions[name], nil
+}
+
+// expired reports whether any action of the object expires
+// within the expiry margin.
+func (o *lfsObject) expired(now time.Time) bool {
+	now = now.Add(lfsExpiryMargin)
+	for _, action := range o.Actions {
+		if action.ExpiresAt != nil && !action.ExpiresAt.IsZero() && now.After(*action.ExpiresAt) {
+			return true
+		}
+		if action.ExpiresIn > 0 && now.After(o.issued.Add(time.Duration(action.ExpiresIn)*time.Second)) {
+			return true
+		}
+	}
+	return false
+}
+
+// upload uploads an object and verifies the upload, if the
+// server requests verification.
+func (t *lfsTransfer) upload(ctx context.Context, obj *structs.LFSPointer, open func(*structs.LFSPointer) (io.ReadCloser, error)) error {
+	for attempt := 0; ; attempt++ {
+		action, err := t.action(ctx, obj, structs.LFSActionUpload)
+		if err != nil {
+			return err
+		}
+		// the server already has the object.
+		if action == nil {
+			return nil
+		}
+		rc, err := open(obj)
+		if err != nil {
+			return err
+		}
+		r := &lfsVerifier{r: rc, obj: obj, hash: sha256.New()}
+		res, err := t.client.LFS.Upload(ctx, action, r, obj.Size)
+		rc.Close()
+		if r.err != nil {
+			return r.err
+		}
+		if err == nil {
+			break
+		}
+		if attempt < t.opts.Retries && t.wait(ctx, res, err, attempt) {
+			continue
+		}
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		action, err := t.action(ctx, obj, structs.LFSActionVerify)
+		if err != nil || action == nil {
+			return err
+		}
+		res, err := t.client.LFS.Verify(ctx, action, obj)
+		if err == nil {
+			return nil
+		}
+		if attempt < t.opts.Retries && t.wait(ctx, res, err, attempt) {
+			continue
+		}
+		return err
+	}
+}
+
+// wait waits before retrying a failed request, and reports
+// whether the failure is transient and the request should be
+// retried.
+func (t *lfsTransfer) wait(ctx context.Context, res *api.Response, err error, attempt int) bool {
+	if ctx.Err() != nil || !transient(res, err) {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(t.opts.Backoff << attempt):
+		return true
+	}
+}
+
+// transient reports whether a failed request may succeed if
+// retried. Requests that failed without a response, such as
+// network errors, are considered transient.
+func transient(res *api.Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	if res == nil {
+		return true
+	}
+	switch res.Status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return res.Status >= 500
+}
+
+// lfsDownload is the verified content of an object being
+// downloaded. Reads that fail are resumed from the offset
+// reached.
+type lfsDownload struct {
+	ctx context.Context
+	t   *lfsTransfer
+	obj *structs.LFSPointer
+
+	rc       io.ReadCloser
+	offset   int64
+	hash     hash.Hash
+	attempts int
+}
+
+func (d *lfsDownload) Read(p []byte) (int, error) {
+	for {
+		if d.rc == nil {
+			if err := d.open(); err != nil {
+				return 0, err
+			}
+		}
+		n, err := d.rc.Read(p)
+		if n > 0 {
+			d.hash.Write(p[:n])
+			d.offset += int64(n)
+			d.attempts = 0
+		}
+		if d.offset > d.obj.Size {
+			return n, ErrLFSObjectMismatch
+		}
+		switch {
+		case err == io.EOF:
+			d.close()
+			return n, d.verify()
+		case err != nil:
+			// the connection failed while streaming the content,
+			// which is resumed from the offset reached.
+			d.close()
+			if d.retry(nil, err) {
+				if n > 0 {
+					return n, nil
+				}
+				continue
+			}
+			return n, err
+		}
+		return n, nil
+	}
+}
+
+// open requests the content of the object from the offset
+// reached, retrying transient failures. If the server does not
+// honor the range request, the content before the offset is
+// skipped.
+func (d *lfsDownload) open() error {
+	for {
+		action, err := d.t.action(d.ctx, d.obj, structs.LFSActionDownload)
+		if err != nil {
+			return err
+		}
+		if action == nil {
+			return fmt.Errorf("lfs object %s: missing download action", d.obj.Oid)
+		}
+		rc, res, err := d.t.client.LFS.Download(d.ctx, action, d.offset)
+		if err != nil {
+			if d.retry(res, err) {
+				continue
+			}
+			return err
+		}
+		if d.offset != 0 && res.Status != http.StatusPartialContent {
+			if _, err := io.CopyN(io.Discard, rc, d.offset); err != nil {
+				rc.Close()
+				if d.retry(nil, err) {
+					continue
+				}
+				return err
+			}
+		}
+		d.rc = rc
+		return nil
+	}
+}
+
+// retry waits before resuming a failed download, and reports
+// whether the download should be resumed. Failures are counted
+// until the download makes progress.
+func (d *lfsDownload) retry(res *api.Response, err error) bool {
+	if d.attempts >= d.t.opts.Retries || !d.t.wait(d.ctx, res, err, d.attempts) {
+		return false
+	}
+	d.attempts++
+	return true
+}
+
+// verify verifies the downloaded content, returning io.EOF if
+// the content matches the object.
+func (d *lfsDownload) verify() error {
+	if d.offset != d.obj.Size || hex.EncodeToString(d.hash.Sum(nil)) != d.obj.Oid {
+		return ErrLFSObjectMismatch
+	}
+	return io.EOF
+}
+
+func (d *lfsDownload) close() {
+	if d.rc != nil {
+		d.rc.Close()
+		d.rc = nil
+	}
+}
+
+// lfsVerifier verifies the content of an object being read. If
+// the content does not match the object, reading fails at the
+// end of the content and the error is retained.
+type lfsVerifier struct {
+	r    io.Reader
+	obj  *structs.LFSPointer
+	hash hash.Hash
+	size int64
+	err  error
+}
+
+func (v *lfsVerifier) Read(p []byte) (int, error) {
+	n, err := v.r.Read(p)
+	v.hash.Write(p[:n])
+	v.size += int64(n)
+	if v.size > v.obj.Size || (err == io.EOF && (v.size != v.obj.Size || hex.EncodeToString(v.hash.Sum(nil)) != v.obj.Oid)) {
+		v.err = ErrLFSObjectMismatch
+		return n, v.err
+	}
+	return n, err
+}
@@ -0,0 +1,268 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func lfsPointer(content string) *structs.LFSPointer {
+	sum := sha256.Sum256([]byte(content))
+	return &structs.LFSPointer{
+		Oid:  hex.EncodeToString(sum[:]),
+		Size: int64(len(content)),
+	}
+}
+
+// failingReader fails after the content is read, simulating a
+// connection that is interrupted while streaming.
+type failingReader struct {
+	r io.Reader
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset by peer")
+	}
+	return n, err
+}
+
+func TestDownloadLFS(t *testing.T) {
+	defer gock.Off()
+
+	a, b := "hello large file storage", "resumable content"
+	pa, pb := lfsPointer(a), lfsPointer(b)
+
+	// the download action of the first object expires within
+	// the expiry margin, so it is renewed before use.
+	gock.New("https://example.gitbundle.com").
+		Post("/octocat/hello-world.git/info/lfs/objects/batch").
+		Reply(200).
+		Type("application/vnd.git-lfs+json").
+		BodyString(fmt.Sprintf(`{"transfer":"basic","objects":[
+			{"oid":%q,"size":%d,"actions":{"download":{"href":"https://example.gitbundle.com/lfs/%s","expires_in":1}}},
+			{"oid":%q,"size":%d,"actions":{"download":{"href":"https://example.gitbundle.com/lfs/%s","header":{"Authorization":"RemoteAuth s3cr3t"}}}}
+		]}`, pa.Oid, pa.Size, pa.Oid, pb.Oid, pb.Size, pb.Oid))
+
+	gock.New("https://example.gitbundle.com").
+		Post("/octocat/hello-world.git/info/lfs/objects/batch").
+		Reply(200).
+		Type("application/vnd.git-lfs+json").
+		BodyString(fmt.Sprintf(`{"objects":[
+			{"oid":%q,"size":%d,"actions":{"download":{"href":"https://example.gitbundle.com/lfs/%s","expires_in":3600}}}
+		]}`, pa.Oid, pa.Size, pa.Oid))
+
+	gock.New("https://example.gitbundle.com").
+		Get("/lfs/" + pa.Oid).
+		Reply(200).
+		BodyString(a)
+
+	// the second object is interrupted after the first bytes,
+	// and resumed with a range request.
+	gock.New("https://example.gitbundle.com").
+		Get("/lfs/"+pb.Oid).
+		MatchHeader("Authorization", "RemoteAuth s3cr3t").
+		MatchHeader("Range", "bytes=9-").
+		Reply(206).
+		BodyString(b[9:])
+
+	gock.New("https://example.gitbundle.com").
+		Get("/lfs/"+pb.Oid).
+		MatchHeader("Authorization", "RemoteAuth s3cr3t").
+		Reply(200).
+		Map(func(res *http.Response) *http.Response {
+			res.Body = ioutil.NopCloser(&failingReader{strings.NewReader(b[:9])})
+			return res
+		})
+
+	var mu sync.Mutex
+	got := map[string]string{}
+	store := func(obj *structs.LFSPointer, r io.Reader) error {
+		raw, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		got[obj.Oid] = string(raw)
+		mu.Unlock()
+		return nil
+	}
+
+	opts := traverse.LFSTransferOptions{Workers: 2, Backoff: time.Millisecond}
+	client, _ := impl.New("https://example.gitbundle.com")
+	err := traverse.DownloadLFS(context.Background(), client, "octocat/hello-world", []*structs.LFSPointer{pa, pb}, store, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{pa.Oid: a, pb.Oid: b}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestDownloadLFS_Mismatch(t *testing.T) {
+	defer gock.Off()
+
+	p := lfsPointer("expected content")
+
+	gock.New("https://example.gitbundle.com").
+		Post("/octocat/hello-world.git/info/lfs/objects/batch").
+		Reply(200).
+		Type("application/vnd.git-lfs+json").
+		BodyString(fmt.Sprintf(`{"objects":[{"oid":%q,"size":%d,"actions":{"download":{"href":"https://example.gitbundle.com/lfs/%s"}}}]}`, p.Oid, p.Size, p.Oid))
+
+	gock.New("https://example.gitbundle.com").
+		Get("/lfs/" + p.Oid).
+		Reply(200).
+		BodyString("tampered content")
+
+	store := func(obj *structs.LFSPointer, r io.Reader) error {
+		_, err := ioutil.ReadAll(r)
+		return err
+	}
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	err := traverse.DownloadLFS(context.Background(), client, "octocat/hello-world", []*structs.LFSPointer{p}, store, traverse.LFSTransferOptions{})
+	if err != traverse.ErrLFSObjectMismatch {
+		t.Errorf("Want object mismatch error, got %v", err)
+	}
+}
+
+func TestDownloadLFS_ObjectError(t *testing.T) {
+	defer gock.Off()
+
+	p := lfsPointer("missing content")
+
+	gock.New("https://example.gitbundle.com").
+		Post("/octocat/hello-world.git/info/lfs/objects/batch").
+		Reply(200).
+		Type("application/vnd.git-lfs+json").
+		BodyString(fmt.Sprintf(`{"objects":[{"oid":%q,"size":%d,"error":{"code":404,"message":"Object does not exist"}}]}`, p.Oid, p.Size))
+
+	store := func(obj *structs.LFSPointer, r io.Reader) error {
+		_, err := ioutil.ReadAll(r)
+		return err
+	}
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	err := traverse.DownloadLFS(context.Background(), client, "octocat/hello-world", []*structs.LFSPointer{p}, store, traverse.LFSTransferOptions{})
+	if err == nil || !strings.Contains(err.Error(), "Object does not exist") {
+		t.Errorf("Want object error, got %v", err)
+	}
+}
+
+func TestUploadLFS(t *testing.T) {
+	defer gock.Off()
+
+	a, b := "new large file", "existing large file"
+	pa, pb := lfsPointer(a), lfsPointer(b)
+
+	// the second object already exists on the server.
+	gock.New("https://example.gitbundle.com").
+		Post("/octocat/hello-world.git/info/lfs/objects/batch").
+		Reply(200).
+		Type("application/vnd.git-lfs+json").
+		BodyString(fmt.Sprintf(`{"transfer":"basic","objects":[
+			{"oid":%q,"size":%d,"actions":{
+				"upload":{"href":"https://example.gitbundle.com/lfs/%s","header":{"Authorization":"RemoteAuth s3cr3t"}},
+				"verify":{"href":"https://example.gitbundle.com/lfs/verify"}
+			}},
+			{"oid":%q,"size":%d}
+		]}`, pa.Oid, pa.Size, pa.Oid, pb.Oid, pb.Size))
+
+	// the first upload attempt fails with a transient error.
+	gock.New("https://example.gitbundle.com").
+		Put("/lfs/" + pa.Oid).
+		Reply(503)
+
+	gock.New("https://example.gitbundle.com").
+		Put("/lfs/"+pa.Oid).
+		MatchHeader("Authorization", "RemoteAuth s3cr3t").
+		MatchHeader("Content-Type", "application/octet-stream").
+		BodyString(a).
+		Reply(200)
+
+	gock.New("https://example.gitbundle.com").
+		Post("/lfs/verify").
+		Reply(200)
+
+	var mu sync.Mutex
+	opened := map[string]int{}
+	content := map[string]string{pa.Oid: a, pb.Oid: b}
+	open := func(obj *structs.LFSPointer) (io.ReadCloser, error) {
+		mu.Lock()
+		opened[obj.Oid]++
+		mu.Unlock()
+		return ioutil.NopCloser(strings.NewReader(content[obj.Oid])), nil
+	}
+
+	opts := traverse.LFSTransferOptions{Ref: "refs/heads/main", Backoff: time.Millisecond}
+	client, _ := impl.New("https://example.gitbundle.com")
+	err := traverse.UploadLFS(context.Background(), client, "octocat/hello-world", []*structs.LFSPointer{pa, pb}, open, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]int{pa.Oid: 2}
+	if diff := cmp.Diff(opened, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestUploadLFS_Mismatch(t *testing.T) {
+	defer gock.Off()
+
+	p := lfsPointer("expected content")
+
+	gock.New("https://example.gitbundle.com").
+		Post("/octocat/hello-world.git/info/lfs/objects/batch").
+		Reply(200).
+		Type("application/vnd.git-lfs+json").
+		BodyString(fmt.Sprintf(`{"objects":[{"oid":%q,"size":%d,"actions":{"upload":{"href":"https://example.gitbundle.com/lfs/%s"}}}]}`, p.Oid, p.Size, p.Oid))
+
+	// the body is matched, so the request body is read.
+	gock.New("https://example.gitbundle.com").
+		Put("/lfs/"+p.Oid).
+		MatchHeader("Content-Type", "application/octet-stream").
+		BodyString("content").
+		Reply(200)
+
+	open := func(obj *structs.LFSPointer) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("tampered content")), nil
+	}
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	err := traverse.UploadLFS(context.Background(), client, "octocat/hello-world", []*structs.LFSPointer{p}, open, traverse.LFSTransferOptions{})
+	if err != traverse.ErrLFSObjectMismatch {
+		t.Errorf("Want object mismatch error, got %v", err)
+	}
+}
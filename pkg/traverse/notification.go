@@ -0,0 +1,178 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// WatchOptions provides options for watching notification
+// threads.
+type WatchOptions struct {
+	// Repo limits the watch to the notification threads of a
+	// repository.
+	Repo string
+
+	// Interval is the polling interval used while new threads
+	// arrive. Defaults to 30 seconds.
+	Interval time.Duration
+
+	// MaxInterval is the polling interval the watcher backs
+	// off to while nothing changes. Defaults to ten times the
+	// Interval.
+	MaxInterval time.Duration
+}
+
+// Watch polls the unread notification threads of the
+// authenticated user and emits new and updated threads on the
+// returned channel until the context is cancelled, after which
+// the channel is closed. Threads that are unread when the watch
+// starts are emitted by the first poll.
+//
+// Requests are conditional, so polling an unchanged list is
+// cheap. The polling interval doubles while nothing changes, up
+// to the MaxInterval, and is reset when new threads arrive. A
+// poll interval requested by the server is never undercut.
+// Failed polls are retried with the same back off.
+func Watch(ctx context.Context, client *api.Client, opts WatchOptions) <-chan *structs.NotificationThread {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.MaxInterval < opts.Interval {
+		opts.MaxInterval = opts.Interval * 10
+	}
+	out := make(chan *structs.NotificationThread)
+	go func() {
+		defer close(out)
+		w := &watcher{client: client, repo: opts.Repo}
+		interval := opts.Interval
+		for {
+			changed, err := w.poll(ctx, out)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil || !changed {
+				interval = interval * 2
+				if interval > opts.MaxInterval {
+					interval = opts.MaxInterval
+				}
+			} else {
+				interval = opts.Interval
+			}
+			wait := interval
+			if w.min > wait {
+				wait = w.min
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+	}()
+	return out
+}
+
+// watcher holds the state of a notification watch between
+// polls.
+type watcher struct {
+	client *api.Client
+	repo   string
+
+	etag     string
+	modified string
+	min      time.Duration
+	seen     map[int64]time.Time
+}
+
+// poll fetches the unread notification threads and emits the
+// threads that are new or updated since the previous poll. It
+// reports whether any thread was emitted.
+func (w *watcher) poll(ctx context.Context, out chan<- *structs.NotificationThread) (bool, error) {
+	opts := api.NotificationListOptions{
+		Size:         50,
+		ETag:         w.etag,
+		LastModified: w.modified,
+	}
+	seen := map[int64]time.Time{}
+	changed := false
+	etag, modified := "", ""
+	for first := true; ; first = false {
+		result, meta, err := w.list(ctx, opts)
+		if err != nil {
+			return changed, err
+		}
+		// the validators of the first page are only retained
+		// once every page has been traversed, so an incomplete
+		// poll is never reported as unchanged.
+		if first {
+			w.interval(meta)
+			if meta.Status == http.StatusNotModified {
+				return false, nil
+			}
+			etag = meta.Header.Get("ETag")
+			modified = meta.Header.Get("Last-Modified")
+			opts.ETag, opts.LastModified = "", ""
+		}
+		for _, src := range result {
+			if src == nil {
+				continue
+			}
+			seen[src.ID] = src.UpdatedAt
+			if last, ok := w.seen[src.ID]; ok && !src.UpdatedAt.After(last) {
+				continue
+			}
+			select {
+			case out <- src:
+				changed = true
+				w.mark(src)
+			case <-ctx.Done():
+				return changed, ctx.Err()
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	w.etag, w.modified = etag, modified
+	w.seen = seen
+	return changed, nil
+}
+
+func (w *watcher) list(ctx context.Context, opts api.NotificationListOptions) ([]*structs.NotificationThread, *api.Response, error) {
+	if w.repo != "" {
+		return w.client.Notifications.ListRepo(ctx, w.repo, opts)
+	}
+	return w.client.Notifications.List(ctx, opts)
+}
+
+// mark records an emitted thread, so it is not emitted again
+// if the poll fails before it completes.
+func (w *watcher) mark(src *structs.NotificationThread) {
+	if w.seen == nil {
+		w.seen = map[int64]time.Time{}
+	}
+	w.seen[src.ID] = src.UpdatedAt
+}
+
+// interval records the minimum poll interval requested by the
+// server with the X-Poll-Interval header.
+func (w *watcher) interval(res *api.Response) {
+	if res.Header == nil {
+		return
+	}
+	if secs, err := strconv.Atoi(res.Header.Get("X-Poll-Interval")); err == nil {
+		w.min = time.Duration(secs) * time.Second
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/h2non/gock"
+)
+
+func TestWatch(t *testing.T) {
+	defer gock.Off()
+
+	// the second poll is not modified, and the third poll
+	// returns the first thread unchanged and a new thread.
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/notifications").
+		MatchHeader("If-None-Match", `"v1"`).
+		Reply(304)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/notifications").
+		MatchHeader("If-None-Match", `"v1"`).
+		Reply(200).
+		Type("application/json").
+		SetHeader("ETag", `"v2"`).
+		BodyString(`[{"id":1,"updated_at":"2023-04-02T08:15:00Z"},{"id":2,"updated_at":"2023-04-02T09:00:00Z"}]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/notifications").
+		Reply(200).
+		Type("application/json").
+		SetHeader("ETag", `"v1"`).
+		BodyString(`[{"id":1,"updated_at":"2023-04-02T08:15:00Z"}]`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	threads := traverse.Watch(ctx, client, traverse.WatchOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 5 * time.Millisecond,
+	})
+
+	got := []int64{}
+	for thread := range threads {
+		got = append(got, thread.ID)
+		if len(got) == 2 {
+			cancel()
+		}
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Want threads [1 2], got %v", got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/internal/semver"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// ErrEmptyRetention is returned when a retention policy does
+// not keep any package version, which would delete every
+// version of the matching packages.
+var ErrEmptyRetention = errors.New("retention policy keeps no package versions")
+
+// ErrUnscopedRetention is returned when a retention policy is
+// not limited by package type or name, which would apply it to
+// every package of the owner.
+var ErrUnscopedRetention = errors.New("retention policy requires a package type or name")
+
+type (
+	// RetentionPolicy declares which package versions are kept.
+	// A version is kept if it is one of the KeepLatest latest
+	// versions of its package, or if it is in the KeepRange
+	// semantic version range. Every other version of the
+	// matching packages is deleted. A policy must be limited
+	// to a package type or name.
+	RetentionPolicy struct {
+		// Type limits the policy to packages of a type.
+		Type string
+
+		// Name limits the policy to a package name.
+		Name string
+
+		// KeepLatest is the number of latest versions kept per
+		// package. Versions are ordered by semantic version if
+		// every version of the package is a semantic version,
+		// and by creation date otherwise.
+		KeepLatest int
+
+		// KeepRange is a semantic version range, such as
+		// ">=1.0.0 <2.0.0" or "^2.1", of versions that are
+		// kept. Versions that are not semantic versions, such
+		// as container tags, are always kept when a range is
+		// set.
+		KeepRange string
+
+		// DryRun reports the versions that would be deleted
+		// without deleting them.
+		DryRun bool
+	}
+
+	// RetentionReport reports the package versions kept and
+	// deleted, or to be deleted in dry-run mode, when applying
+	// a retention policy.
+	RetentionReport struct {
+		DryRun  bool
+		Kept    []*structs.Package
+		Deleted []*structs.Package
+	}
+)
+
+// Packages returns the full list of package versions of the
+// owner, traversing and combining paginated responses if
+// necessary.
+func Packages(ctx context.Context, client *api.Client, owner string, opts api.PackageListOptions) ([]*structs.Package, error) {
+	list := []*structs.Package{}
+	if opts.Size == 0 {
+		opts.Size = 50
+	}
+	for {
+		result, meta, err := client.Packages.List(ctx, owner, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range result {
+			if src != nil {
+				list = append(list, src)
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return list, nil
+}
+
+// PackageVersions returns the versions of a package, latest
+// first.
+func PackageVersions(ctx context.Context, client *api.Client, owner, kind, name string) ([]*structs.Package, error) {
+	list, err := Packages(ctx, client, owner, api.PackageListOptions{Type: kind, Query: name})
+	if err != nil {
+		return nil, err
+	}
+	// the query matches package names partially.
+	versions := []*structs.Package{}
+	for _, pkg := range list {
+		if pkg.Type == kind && pkg.Name == name {
+			versions = append(versions, pkg)
+		}
+	}
+	sortVersions(versions)
+	return versions, nil
+}
+
+// ApplyRetention applies the retention policy to the packages
+// of the owner, deleting the versions that are not kept unless
+// running in dry-run mode.
+func ApplyRetention(ctx context.Context, client *api.Client, owner string, policy RetentionPolicy) (*RetentionReport, error) {
+	if policy.KeepLatest <= 0 && policy.KeepRange == "" {
+		return nil, ErrEmptyRetention
+	}
+	if policy.Type == "" && policy.Name == "" {
+		return nil, ErrUnscopedRetention
+	}
+	var keep *semver.Constraint
+	if policy.KeepRange != "" {
+		var err error
+		keep, err = semver.ParseConstraint(policy.KeepRange)
+		if err != nil {
+			return nil, err
+		}
+	}
+	list, err := Packages(ctx, client, owner, api.PackageListOptions{Type: policy.Type, Query: policy.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	// group the versions by package, retaining the order in
+	// which the packages are first listed.
+	keys := []string{}
+	groups := map[string][]*structs.Package{}
+	for _, pkg := range list {
+		// the server matches package names partially.
+		if policy.Name != "" && pkg.Name != policy.Name {
+			continue
+		}
+		if policy.Type != "" && pkg.Type != policy.Type {
+			continue
+		}
+		key := pkg.Type + "/" + pkg.Name
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], pkg)
+	}
+
+	report := &RetentionReport{DryRun: policy.DryRun}
+	for _, key := range keys {
+		versions := groups[key]
+		sortVersions(versions)
+		for i, pkg := range versions {
+			if i < policy.KeepLatest || keepVersion(keep, pkg.Version) {
+				report.Kept = append(report.Kept, pkg)
+				continue
+			}
+			if !policy.DryRun {
+				if _, err := client.Packages.Delete(ctx, owner, pkg.Type, pkg.Name, pkg.Version); err != nil {
+					return report, err
+				}
+			}
+			report.Deleted = append(report.Deleted, pkg)
+		}
+	}
+	return report, nil
+}
+
+// sortVersions sorts the versions of a package latest first,
+// by semantic version if every version is a semantic version,
+// and by creation date otherwise.
+func sortVersions(versions []*structs.Package) {
+	parsed := make([]*semver.Version, len(versions))
+	for i, pkg := range versions {
+		v, err := semver.Parse(pkg.Version)
+		if err != nil {
+			sort.SliceStable(versions, func(i, j int) bool {
+				return versions[i].CreatedAt.After(versions[j].CreatedAt)
+			})
+			return
+		}
+		parsed[i] = v
+	}
+	sort.Sort(&byVersion{versions, parsed})
+}
+
+// keepVersion reports whether the version is kept by the
+// range. Versions that are not semantic versions cannot be
+// compared with the range, so they are kept.
+func keepVersion(c *semver.Constraint, version string) bool {
+	if c == nil {
+		return false
+	}
+	v, err := semver.Parse(version)
+	if err != nil {
+		return true
+	}
+	return c.Check(v)
+}
+
+// byVersion sorts package versions by descending semantic
+// version.
+type byVersion struct {
+	list   []*structs.Package
+	parsed []*semver.Version
+}
+
+func (s *byVersion) Len() int           { return len(s.list) }
+func (s *byVersion) Less(i, j int) bool { return s.parsed[i].Compare(s.parsed[j]) > 0 }
+func (s *byVersion) Swap(i, j int) {
+	s.list[i], s.list[j] = s.list[j], s.list[i]
+	s.parsed[i], s.parsed[j] = s.parsed[j], s.parsed[i]
+}
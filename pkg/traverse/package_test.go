@@ -0,0 +1,221 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/structs"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func mockPackages() {
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/packages/octocat").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[
+			{"id":4,"type":"npm","name":"hello-world","version":"2.0.0-rc.1","created_at":"2023-03-04T00:00:00Z"},
+			{"id":5,"type":"container","name":"hello-world","version":"latest","created_at":"2023-03-05T00:00:00Z"},
+			{"id":6,"type":"container","name":"hello-world","version":"nightly","created_at":"2023-03-02T00:00:00Z"}
+		]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/packages/octocat").
+		Reply(200).
+		Type("application/json").
+		SetHeader("Link", `<https://example.gitbundle.com/api/v1/packages/octocat?page=2&limit=50>; rel="next"`).
+		BodyString(`[
+			{"id":1,"type":"npm","name":"hello-world","version":"1.10.0","created_at":"2023-03-01T00:00:00Z"},
+			{"id":2,"type":"npm","name":"hello-world","version":"1.9.0","created_at":"2023-03-03T00:00:00Z"},
+			{"id":3,"type":"npm","name":"hello-world","version":"0.9.0","created_at":"2023-02-01T00:00:00Z"}
+		]`)
+}
+
+func packageIDs(list []*structs.Package) []int64 {
+	ids := []int64{}
+	for _, pkg := range list {
+		ids = append(ids, pkg.ID)
+	}
+	return ids
+}
+
+func TestPackageVersions(t *testing.T) {
+	defer gock.Off()
+
+	mockPackages()
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.PackageVersions(context.Background(), client, "octocat", "npm", "hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(packageIDs(got), []int64{4, 1, 2, 3}); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestApplyRetention_DryRun(t *testing.T) {
+	defer gock.Off()
+
+	mockPackages()
+
+	policy := traverse.RetentionPolicy{
+		Name:       "hello-world",
+		KeepLatest: 1,
+		DryRun:     true,
+	}
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.ApplyRetention(context.Background(), client, "octocat", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.DryRun {
+		t.Errorf("Want dry run report")
+	}
+	// the container versions are not semantic versions, so
+	// they are ordered by creation date.
+	if diff := cmp.Diff(packageIDs(got.Kept), []int64{4, 5}); diff != "" {
+		t.Errorf("Unexpected kept versions")
+		t.Log(diff)
+	}
+	if diff := cmp.Diff(packageIDs(got.Deleted), []int64{1, 2, 3, 6}); diff != "" {
+		t.Errorf("Unexpected deleted versions")
+		t.Log(diff)
+	}
+}
+
+func TestApplyRetention(t *testing.T) {
+	defer gock.Off()
+
+	mockPackages()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/packages/octocat/npm/hello-world/0.9.0").
+		Reply(204)
+
+	policy := traverse.RetentionPolicy{
+		Name:       "hello-world",
+		KeepLatest: 1,
+		KeepRange:  ">=1.9.0 <2.0.0",
+	}
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.ApplyRetention(context.Background(), client, "octocat", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the container versions are not semantic versions, so
+	// they are kept by the range.
+	if diff := cmp.Diff(packageIDs(got.Kept), []int64{4, 1, 2, 5, 6}); diff != "" {
+		t.Errorf("Unexpected kept versions")
+		t.Log(diff)
+	}
+	if diff := cmp.Diff(packageIDs(got.Deleted), []int64{3}); diff != "" {
+		t.Errorf("Unexpected deleted versions")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestApplyRetention_RangeOnly(t *testing.T) {
+	defer gock.Off()
+
+	mockPackages()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/packages/octocat/npm/hello-world/0.9.0").
+		Reply(204)
+
+	policy := traverse.RetentionPolicy{
+		Name:      "hello-world",
+		KeepRange: ">=1.0.0",
+	}
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.ApplyRetention(context.Background(), client, "octocat", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tags such as latest and nightly are never deleted by
+	// a range-based policy.
+	if diff := cmp.Diff(packageIDs(got.Deleted), []int64{3}); diff != "" {
+		t.Errorf("Unexpected deleted versions")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestApplyRetention_Type(t *testing.T) {
+	defer gock.Off()
+
+	mockPackages()
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/packages/octocat/container/hello-world/nightly").
+		Reply(204)
+
+	policy := traverse.RetentionPolicy{
+		Type:       "container",
+		KeepLatest: 1,
+	}
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.ApplyRetention(context.Background(), client, "octocat", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(packageIDs(got.Deleted), []int64{6}); diff != "" {
+		t.Errorf("Unexpected deleted versions")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestApplyRetention_Empty(t *testing.T) {
+	client, _ := impl.New("https://example.gitbundle.com")
+	_, err := traverse.ApplyRetention(context.Background(), client, "octocat", traverse.RetentionPolicy{Name: "hello-world"})
+	if err != traverse.ErrEmptyRetention {
+		t.Errorf("Want empty retention error, got %v", err)
+	}
+}
+
+func TestApplyRetention_Unscoped(t *testing.T) {
+	client, _ := impl.New("https://example.gitbundle.com")
+	_, err := traverse.ApplyRetention(context.Background(), client, "octocat", traverse.RetentionPolicy{KeepRange: "^1.0"})
+	if err != traverse.ErrUnscopedRetention {
+		t.Errorf("Want unscoped retention error, got %v", err)
+	}
+}
+
+func TestPackages_Size(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/packages/octocat").
+		MatchParam("limit", "10").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[]`)
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	_, err := traverse.Packages(context.Background(), client, "octocat", api.PackageListOptions{Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending API calls")
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// ownersTeam is the name of the organization owners team,
+// which cannot be deleted.
+const ownersTeam = "Owners"
+
+// TeamAction identifies a change applied when reconciling
+// organization teams.
+type TeamAction int
+
+// TeamAction values.
+const (
+	TeamCreate TeamAction = iota
+	TeamUpdate
+	TeamDelete
+	TeamAddMember
+	TeamRemoveMember
+	TeamAddRepo
+	TeamRemoveRepo
+)
+
+// String returns the string representation of TeamAction.
+func (a TeamAction) String() string {
+	switch a {
+	case TeamCreate:
+		return "create"
+	case TeamUpdate:
+		return "update"
+	case TeamDelete:
+		return "delete"
+	case TeamAddMember:
+		return "add member"
+	case TeamRemoveMember:
+		return "remove member"
+	case TeamAddRepo:
+		return "add repo"
+	case TeamRemoveRepo:
+		return "remove repo"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// TeamSpec declares the desired state of an organization
+	// team. Repositories are given by name or by full name.
+	// Repositories are not reconciled for teams that include
+	// all repositories. Empty unit permissions are treated as
+	// none, and an empty permission leaves the team permission
+	// unchanged.
+	TeamSpec struct {
+		Name                    string
+		Description             string
+		Permission              string
+		Units                   api.UnitsMap
+		IncludesAllRepositories bool
+		CanCreateOrgRepo        bool
+		Members                 []string
+		Repos                   []string
+	}
+
+	// TeamChange represents a change computed, and unless
+	// running in dry-run mode applied, when reconciling
+	// organization teams. Target is the member login or the
+	// repository full name, if applicable.
+	TeamChange struct {
+		Team   string
+		Action TeamAction
+		Target string
+	}
+
+	// ReconcileOptions provides options for reconciling
+	// organization teams.
+	ReconcileOptions struct {
+		// DryRun computes the changes without applying them.
+		DryRun bool
+
+		// Prune deletes teams that are not declared in the
+		// spec. The owners team is never deleted.
+		Prune bool
+	}
+)
+
+// Teams returns the full organization team list, traversing
+// and combining paginated responses if necessary.
+func Teams(ctx context.Context, client *api.Client, org string) ([]*api.Team, error) {
+	list := []*api.Team{}
+	opts := api.ListOptions{Size: 50}
+	for {
+		result, meta, err := client.Teams.List(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range result {
+			if src != nil {
+				list = append(list, src)
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return list, nil
+}
+
+// TeamMembers returns the full team member list, traversing
+// and combining paginated responses if necessary.
+func TeamMembers(ctx context.Context, client *api.Client, id int64) ([]*api.User, error) {
+	list := []*api.User{}
+	opts := api.ListOptions{Size: 50}
+	for {
+		result, meta, err := client.Teams.ListMembers(ctx, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range result {
+			if src != nil {
+				list = append(list, src)
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return list, nil
+}
+
+// TeamRepos returns the full team repository list, traversing
+// and combining paginated responses if necessary.
+func TeamRepos(ctx context.Context, client *api.Client, id int64) ([]*api.Repository, error) {
+	list := []*api.Repository{}
+	opts := api.ListOptions{Size: 50}
+	for {
+		result, meta, err := client.Teams.ListRepos(ctx, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range result {
+			if src != nil {
+				list = append(list, src)
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return list, nil
+}
+
+// ReconcileTeams reconciles the organization teams with the
+// spec, creating and updating teams and adding and removing
+// team members and repositories as needed. It returns the
+// changes applied, or the changes that would be applied in
+// dry-run mode. Reconciliation stops at the first error, in
+// which case the changes applied so far are returned with the
+// error.
+func ReconcileTeams(ctx context.Context, client *api.Client, org string, specs []*TeamSpec, opts ReconcileOptions) ([]*TeamChange, error) {
+	teams, err := Teams(ctx, client, org)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]*api.Team{}
+	for _, team := range teams {
+		existing[strings.ToLower(team.Name)] = team
+	}
+
+	r := &reconciler{client: client, org: org, dryRun: opts.DryRun}
+	declared := map[string]bool{}
+	for _, spec := range specs {
+		declared[strings.ToLower(spec.Name)] = true
+		if err := r.reconcile(ctx, existing[strings.ToLower(spec.Name)], spec); err != nil {
+			return r.changes, err
+		}
+	}
+	if !opts.Prune {
+		return r.changes, nil
+	}
+	for _, team := range teams {
+		if declared[strings.ToLower(team.Name)] || team.Name == ownersTeam {
+			continue
+		}
+		if err := r.apply(team.Name, TeamDelete, "", func() error {
+			_, err := client.Teams.Delete(ctx, team.ID)
+			return err
+		}); err != nil {
+			return r.changes, err
+		}
+	}
+	return r.changes, nil
+}
+
+type reconciler struct {
+	client  *api.Client
+	org     string
+	dryRun  bool
+	changes []*TeamChange
+}
+
+// apply records the change and, unless running in dry-run
+// mode, applies it.
+func (r *reconciler) apply(team string, action TeamAction, target string, fn func() error) error {
+	if !r.dryRun {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	r.changes = append(r.changes, &TeamChange{Team: team, Action: action, Target: target})
+	return nil
+}
+
+func (r *reconciler) reconcile(ctx context.Context, team *api.Team, spec *TeamSpec) error {
+	var members []*api.User
+	var repos []*api.Repository
+	var err error
+
+	switch {
+	case team == nil:
+		in := &structs.CreateTeamOption{
+			Name:                    spec.Name,
+			Description:             spec.Description,
+			IncludesAllRepositories: spec.IncludesAllRepositories,
+			Permission:              spec.Permission,
+			UnitsMap:                unitsMap(spec.Units),
+			CanCreateOrgRepo:        spec.CanCreateOrgRepo,
+		}
+		team = &api.Team{Name: spec.Name}
+		err = r.apply(spec.Name, TeamCreate, "", func() error {
+			team, _, err = r.client.Teams.Create(ctx, r.org, in)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	default:
+		if teamChanged(team, spec) {
+			in := &structs.EditTeamOption{
+				Name:                    team.Name,
+				Description:             &spec.Description,
+				IncludesAllRepositories: &spec.IncludesAllRepositories,
+				Permission:              spec.Permission,
+				UnitsMap:                unitsMap(spec.Units),
+				CanCreateOrgRepo:        &spec.CanCreateOrgRepo,
+			}
+			if err := r.apply(team.Name, TeamUpdate, "", func() error {
+				_, _, err := r.client.Teams.Update(ctx, team.ID, in)
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+		if members, err = TeamMembers(ctx, r.client, team.ID); err != nil {
+			return err
+		}
+		if !spec.IncludesAllRepositories {
+			if repos, err = TeamRepos(ctx, r.client, team.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	current := map[string]bool{}
+	for _, member := range members {
+		current[strings.ToLower(member.Login)] = true
+	}
+	desired := map[string]bool{}
+	for _, login := range spec.Members {
+		desired[strings.ToLower(login)] = true
+		if current[strings.ToLower(login)] {
+			continue
+		}
+		if err := r.apply(team.Name, TeamAddMember, login, func() error {
+			_, err := r.client.Teams.AddMember(ctx, team.ID, login)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	for _, member := range members {
+		if desired[strings.ToLower(member.Login)] {
+			continue
+		}
+		if err := r.apply(team.Name, TeamRemoveMember, member.Login, func() error {
+			_, err := r.client.Teams.RemoveMember(ctx, team.ID, member.Login)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	if spec.IncludesAllRepositories {
+		return nil
+	}
+	current = map[string]bool{}
+	for _, repo := range repos {
+		current[strings.ToLower(api.Join(repo.Namespace, repo.Name))] = true
+	}
+	desired = map[string]bool{}
+	for _, name := range spec.Repos {
+		if !strings.Contains(name, "/") {
+			name = api.Join(r.org, name)
+		}
+		desired[strings.ToLower(name)] = true
+		if current[strings.ToLower(name)] {
+			continue
+		}
+		if err := r.apply(team.Name, TeamAddRepo, name, func() error {
+			_, err := r.client.Teams.AddRepo(ctx, team.ID, name)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	for _, repo := range repos {
+		name := api.Join(repo.Namespace, repo.Name)
+		if desired[strings.ToLower(name)] {
+			continue
+		}
+		if err := r.apply(team.Name, TeamRemoveRepo, name, func() error {
+			_, err := r.client.Teams.RemoveRepo(ctx, team.ID, name)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// teamChanged returns true if the team settings differ from
+// the spec. The permission is only compared when the spec
+// sets it.
+func teamChanged(team *api.Team, spec *TeamSpec) bool {
+	return team.Description != spec.Description ||
+		(spec.Permission != "" && team.Permission != spec.Permission) ||
+		team.IncludesAllRepositories != spec.IncludesAllRepositories ||
+		team.CanCreateOrgRepo != spec.CanCreateOrgRepo ||
+		normalizeUnits(team.UnitsMap) != normalizeUnits(spec.Units)
+}
+
+// normalizeUnits treats empty unit permissions as none, so
+// that an unset unit compares equal to a unit without access.
+func normalizeUnits(u api.UnitsMap) api.UnitsMap {
+	for _, p := range []*string{&u.Code, &u.ExtIssues, &u.ExtWiki, &u.Issues, &u.Packages, &u.Projects, &u.Pulls, &u.Releases, &u.Wiki} {
+		if *p == "" {
+			*p = "none"
+		}
+	}
+	return u
+}
+
+// unitsMap returns the unit permissions keyed by unit name,
+// omitting units without a permission.
+func unitsMap(u api.UnitsMap) map[string]string {
+	to := map[string]string{}
+	for k, v := range map[string]string{
+		"repo.code":       u.Code,
+		"repo.ext_issues": u.ExtIssues,
+		"repo.ext_wiki":   u.ExtWiki,
+		"repo.issues":     u.Issues,
+		"repo.packages":   u.Packages,
+		"repo.projects":   u.Projects,
+		"repo.pulls":      u.Pulls,
+		"repo.releases":   u.Releases,
+		"repo.wiki":       u.Wiki,
+	} {
+		if v != "" {
+			to[k] = v
+		}
+	}
+	if len(to) == 0 {
+		return nil
+	}
+	return to
+}
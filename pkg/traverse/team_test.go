@@ -0,0 +1,185 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+var testTeamSpecs = []*traverse.TeamSpec{
+	{
+		Name:       "developers",
+		Permission: "write",
+		Units:      api.UnitsMap{Code: "write", Pulls: "write"},
+		Members:    []string{"jcitizen", "janedoe"},
+		Repos:      []string{"api", "gogits/web"},
+	},
+	{
+		Name:       "qa",
+		Permission: "read",
+		Units:      api.UnitsMap{Code: "read"},
+		Members:    []string{"octocat"},
+	},
+}
+
+func mockTeams() {
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/orgs/gogits/teams").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[
+			{"id":1,"name":"Owners","permission":"owner"},
+			{"id":2,"name":"developers","permission":"write","units_map":{"repo.code":"write","repo.pulls":"write","repo.wiki":"none"}},
+			{"id":3,"name":"contractors","permission":"read"}
+		]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/teams/2/members").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"id":1,"login":"jcitizen"},{"id":4,"login":"former"}]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/teams/2/repos").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"id":1,"name":"api","owner":{"login":"gogits"}},{"id":3,"name":"legacy","owner":{"login":"gogits"}}]`)
+}
+
+func TestReconcileTeams(t *testing.T) {
+	defer gock.Off()
+
+	mockTeams()
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/teams/2/members/janedoe").
+		Reply(204)
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/teams/2/members/former").
+		Reply(204)
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/teams/2/repos/gogits/web").
+		Reply(204)
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/teams/2/repos/gogits/legacy").
+		Reply(204)
+
+	gock.New("https://example.gitbundle.com").
+		Post("/api/v1/orgs/gogits/teams").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"name":                      "qa",
+			"description":               "",
+			"includes_all_repositories": false,
+			"permission":                "read",
+			"units":                     nil,
+			"units_map":                 map[string]string{"repo.code": "read"},
+			"can_create_org_repo":       false,
+		}).
+		Reply(201).
+		Type("application/json").
+		BodyString(`{"id":5,"name":"qa","permission":"read"}`)
+
+	gock.New("https://example.gitbundle.com").
+		Put("/api/v1/teams/5/members/octocat").
+		Reply(204)
+
+	gock.New("https://example.gitbundle.com").
+		Delete("/api/v1/teams/3").
+		Reply(204)
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.ReconcileTeams(context.Background(), client, "gogits", testTeamSpecs, traverse.ReconcileOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*traverse.TeamChange{
+		{Team: "developers", Action: traverse.TeamAddMember, Target: "janedoe"},
+		{Team: "developers", Action: traverse.TeamRemoveMember, Target: "former"},
+		{Team: "developers", Action: traverse.TeamAddRepo, Target: "gogits/web"},
+		{Team: "developers", Action: traverse.TeamRemoveRepo, Target: "gogits/legacy"},
+		{Team: "qa", Action: traverse.TeamCreate},
+		{Team: "qa", Action: traverse.TeamAddMember, Target: "octocat"},
+		{Team: "contractors", Action: traverse.TeamDelete},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
+func TestReconcileTeams_DryRun(t *testing.T) {
+	defer gock.Off()
+
+	mockTeams()
+
+	specs := []*traverse.TeamSpec{
+		{
+			Name:        "developers",
+			Description: "Product developers",
+			Permission:  "write",
+			Units:       api.UnitsMap{Code: "write", Pulls: "write"},
+			Members:     []string{"jcitizen", "former"},
+			Repos:       []string{"api", "legacy"},
+		},
+	}
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.ReconcileTeams(context.Background(), client, "gogits", specs, traverse.ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*traverse.TeamChange{
+		{Team: "developers", Action: traverse.TeamUpdate},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReconcileTeams_Unchanged(t *testing.T) {
+	defer gock.Off()
+
+	specs := []*traverse.TeamSpec{
+		{
+			Name:    "developers",
+			Units:   api.UnitsMap{Code: "write", Pulls: "write"},
+			Members: []string{"jcitizen", "former"},
+			Repos:   []string{"api", "legacy"},
+		},
+	}
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	for i := 0; i < 2; i++ {
+		mockTeams()
+
+		got, err := traverse.ReconcileTeams(context.Background(), client, "gogits", specs, traverse.ReconcileOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Errorf("Want no changes, got %d", len(got))
+		}
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
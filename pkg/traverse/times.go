@@ -0,0 +1,77 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+	"time"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// TimeReport represents the time tracked in a repository,
+// totalled by user and by milestone. Time tracked on issues
+// without a milestone is reported under the empty title.
+type TimeReport struct {
+	Repo        string
+	Total       time.Duration
+	ByUser      map[string]time.Duration
+	ByMilestone map[string]time.Duration
+}
+
+// Times returns the full list of times tracked in the
+// repository, traversing and combining paginated responses
+// if necessary.
+func Times(ctx context.Context, client *api.Client, repo string, opts api.TrackedTimeListOptions) (structs.TrackedTimeList, error) {
+	list := structs.TrackedTimeList{}
+	if opts.Size == 0 {
+		opts.Size = 100
+	}
+	for {
+		result, meta, err := client.Times.ListRepo(ctx, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range result {
+			if src != nil {
+				list = append(list, src)
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return list, nil
+}
+
+// RepoTimeReport returns the time tracked in the repository,
+// totalled by user and by milestone.
+func RepoTimeReport(ctx context.Context, client *api.Client, repo string, opts api.TrackedTimeListOptions) (*TimeReport, error) {
+	times, err := Times(ctx, client, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	report := &TimeReport{
+		Repo:        repo,
+		ByUser:      map[string]time.Duration{},
+		ByMilestone: map[string]time.Duration{},
+	}
+	for _, src := range times {
+		d := time.Duration(src.Time) * time.Second
+		report.Total += d
+		report.ByUser[src.UserName] += d
+
+		var milestone string
+		if src.Issue != nil && src.Issue.Milestone != nil {
+			milestone = src.Issue.Milestone.Title
+		}
+		report.ByMilestone[milestone] += d
+	}
+	return report, nil
+}
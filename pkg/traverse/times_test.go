@@ -0,0 +1,63 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestRepoTimeReport(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/times").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[
+			{"id":3,"time":1800,"user_name":"janedoe","issue":{"number":2}}
+		]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/repos/go-magit/magit/times").
+		Reply(200).
+		Type("application/json").
+		SetHeader("Link", `<https://example.gitbundle.com/api/v1/repos/go-magit/magit/times?page=2&limit=100>; rel="next"`).
+		BodyString(`[
+			{"id":1,"time":3600,"user_name":"janedoe","issue":{"number":1,"milestone":{"title":"v1.0"}}},
+			{"id":2,"time":600,"user_name":"johnsmith","issue":{"number":1,"milestone":{"title":"v1.0"}}}
+		]`)
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.RepoTimeReport(context.Background(), client, "go-magit/magit", api.TrackedTimeListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &traverse.TimeReport{
+		Repo:  "go-magit/magit",
+		Total: 100 * time.Minute,
+		ByUser: map[string]time.Duration{
+			"janedoe":   90 * time.Minute,
+			"johnsmith": 10 * time.Minute,
+		},
+		ByMilestone: map[string]time.Duration{
+			"v1.0": 70 * time.Minute,
+			"":     30 * time.Minute,
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"context"
+
+	api "github.com/gitbundle/api"
+)
+
+// EachUser calls fn for every user account on the server,
+// traversing paginated responses as needed. Traversal stops
+// at the first error returned by fn, which is returned to
+// the caller. It requires the authenticated user to be a
+// site administrator.
+func EachUser(ctx context.Context, client *api.Client, fn func(*api.User) error) error {
+	opts := api.ListOptions{Size: 50}
+	for {
+		result, meta, err := client.Admin.ListUsers(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, src := range result {
+			if src == nil {
+				continue
+			}
+			if err := fn(src); err != nil {
+				return err
+			}
+		}
+		opts.Page = meta.Page.Next
+		opts.URL = meta.Page.NextURL
+
+		if opts.Page == 0 && opts.URL == "" {
+			break
+		}
+	}
+	return nil
+}
+
+// Users returns the full list of user accounts on the server,
+// traversing and combining paginated responses if necessary.
+func Users(ctx context.Context, client *api.Client) ([]*api.User, error) {
+	list := []*api.User{}
+	err := EachUser(ctx, client, func(user *api.User) error {
+		list = append(list, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
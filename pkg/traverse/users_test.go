@@ -0,0 +1,70 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/gitbundle/api"
+	"github.com/gitbundle/api/pkg/impl"
+	"github.com/gitbundle/api/pkg/traverse"
+	"github.com/h2non/gock"
+)
+
+func mockUsers() {
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/admin/users").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"id":3,"login":"octocat"}]`)
+
+	gock.New("https://example.gitbundle.com").
+		Get("/api/v1/admin/users").
+		Reply(200).
+		Type("application/json").
+		SetHeader("Link", `<https://example.gitbundle.com/api/v1/admin/users?page=2&limit=50>; rel="next"`).
+		BodyString(`[{"id":1,"login":"jcitizen"},{"id":2,"login":"janedoe"}]`)
+}
+
+func TestUsers(t *testing.T) {
+	defer gock.Off()
+
+	mockUsers()
+
+	client, _ := impl.New("https://example.gitbundle.com")
+	got, err := traverse.Users(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Errorf("Want 3 users, got %d", len(got))
+	}
+}
+
+func TestEachUser_Stop(t *testing.T) {
+	defer gock.Off()
+
+	mockUsers()
+
+	stop := errors.New("stop")
+	count := 0
+	client, _ := impl.New("https://example.gitbundle.com")
+	err := traverse.EachUser(context.Background(), client, func(user *api.User) error {
+		count++
+		if user.Login == "janedoe" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("Want stop error, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Want 2 users visited, got %d", count)
+	}
+}
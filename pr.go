@@ -7,30 +7,32 @@ package api
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
 type (
 	// PullRequest represents a repository pull request.
 	PullRequest struct {
-		Number  int
-		Title   string
-		Body    string
-		Sha     string
-		Ref     string
-		Source  string
-		Target  string
-		Fork    string
-		Link    string
-		Diff    string
-		Closed  bool
-		Merged  bool
-		Base    Reference
-		Head    Reference
-		Author  User
-		Created time.Time
-		Updated time.Time
-		Labels  []Label
+		Number    int
+		Title     string
+		Body      string
+		Sha       string
+		Ref       string
+		Source    string
+		Target    string
+		Fork      string
+		Link      string
+		Diff      string
+		Closed    bool
+		Merged    bool
+		Base      Reference
+		Head      Reference
+		Author    User
+		Created   time.Time
+		Updated   time.Time
+		Labels    []Label
+		Reactions Reactions
 	}
 
 	// PullRequestInput provides the input fields required for creating a pull request.
@@ -41,13 +43,33 @@ type (
 		Target string
 	}
 
+	// PullRequestUpdateInput provides the input fields for
+	// updating a pull request. Nil fields are left unchanged,
+	// and an empty body clears the pull request body.
+	PullRequestUpdateInput struct {
+		Title  *string
+		Body   *string
+		Target *string
+	}
+
 	// PullRequestListOptions provides options for querying
-	// a list of repository merge requests.
+	// a list of repository merge requests. The filters have
+	// the same meaning as in IssueListOptions.
 	PullRequestListOptions struct {
-		Page   int
-		Size   int
-		Open   bool
-		Closed bool
+		URL        string
+		Page       int
+		Size       int
+		Open       bool
+		Closed     bool
+		Labels     []string
+		Milestones []string
+		Assignee   string
+		Author     string
+		Mentioned  string
+		Since      time.Time
+		Before     time.Time
+		Keyword    string
+		Sort       string
 	}
 
 	// Change represents a changed file.
@@ -97,6 +119,15 @@ type (
 		// ListCommits returns the pull request commit list.
 		ListCommits(context.Context, string, int, ListOptions) ([]*Commit, *Response, error)
 
+		// Diff returns the raw diff of the pull request. The
+		// caller is responsible for closing the returned reader.
+		Diff(context.Context, string, int) (io.ReadCloser, *Response, error)
+
+		// Patch returns the pull request commits formatted as
+		// email patches. The caller is responsible for closing
+		// the returned reader.
+		Patch(context.Context, string, int) (io.ReadCloser, *Response, error)
+
 		// Merge merges the repository pull request.
 		Merge(context.Context, string, int) (*Response, error)
 
@@ -106,9 +137,16 @@ type (
 		// Create creates a new pull request.
 		Create(context.Context, string, *PullRequestInput) (*PullRequest, *Response, error)
 
+		// Update updates the title, body and target branch of
+		// a pull request.
+		Update(context.Context, string, int, *PullRequestUpdateInput) (*PullRequest, *Response, error)
+
 		// CreateComment creates a new pull request comment.
 		CreateComment(context.Context, string, int, *CommentInput) (*Comment, *Response, error)
 
+		// UpdateComment updates a pull request comment.
+		UpdateComment(context.Context, string, int, int, *CommentInput) (*Comment, *Response, error)
+
 		// DeleteComment deletes an pull request comment.
 		DeleteComment(context.Context, string, int, int) (*Response, error)
 	}
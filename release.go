@@ -6,7 +6,10 @@ package api
 
 import (
 	"context"
+	"io"
 	"time"
+
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type (
@@ -22,6 +25,7 @@ type (
 		Prerelease  bool
 		Created     time.Time
 		Published   time.Time
+		Assets      []*structs.Attachment
 	}
 
 	// ReleaseInput contains the information needed to create a release
@@ -36,6 +40,7 @@ type (
 
 	// ReleaseListOptions provides options for querying a list of repository releases.
 	ReleaseListOptions struct {
+		URL    string
 		Page   int
 		Size   int
 		Open   bool
@@ -67,5 +72,25 @@ type (
 
 		// DeleteByTag deletes a release in the given repository by tag
 		DeleteByTag(context.Context, string, string) (*Response, error)
+
+		// ListAssets returns the assets of a release in the given repository
+		ListAssets(context.Context, string, int, ListOptions) ([]*structs.Attachment, *Response, error)
+
+		// FindAsset returns a release asset by id
+		FindAsset(context.Context, string, int, int64) (*structs.Attachment, *Response, error)
+
+		// UploadAsset uploads a release asset with the given file name,
+		// streaming the content from the reader
+		UploadAsset(context.Context, string, int, string, io.Reader) (*structs.Attachment, *Response, error)
+
+		// UpdateAsset updates a release asset
+		UpdateAsset(context.Context, string, int, int64, *structs.EditAttachmentOptions) (*structs.Attachment, *Response, error)
+
+		// DeleteAsset deletes a release asset
+		DeleteAsset(context.Context, string, int, int64) (*Response, error)
+
+		// DownloadAsset returns the content of a release asset. The
+		// caller is responsible for closing the returned reader.
+		DownloadAsset(context.Context, string, int, int64) (io.ReadCloser, *Response, error)
 	}
 )
@@ -7,11 +7,19 @@ package api
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/gitbundle/api/pkg/structs"
 )
 
+// Repository archive formats.
+const (
+	ArchiveTarGz  = "tar.gz"
+	ArchiveZip    = "zip"
+	ArchiveBundle = "bundle"
+)
+
 type (
 	// Repository represents a git repository.
 	Repository struct {
@@ -147,6 +155,11 @@ type (
 		// FindHook returns a repository hook.
 		FindHook(context.Context, string, string) (*Hook, *Response, error)
 
+		// Archive returns a snapshot of the repository at ref
+		// in the archive format, such as ArchiveTarGz. The
+		// caller is responsible for closing the returned reader.
+		Archive(ctx context.Context, repo, ref, format string) (io.ReadCloser, *Response, error)
+
 		// FindPerms returns repository permissions.
 		FindPerms(context.Context, string) (*Perm, *Response, error)
 
@@ -174,8 +187,51 @@ type (
 		CheckCollaborator(context.Context, string, string) (bool, *Response, error)
 		CheckCollaboratorPermission(ctx context.Context, repo string, collaborator string) (*structs.RepoCollaboratorPermission, *Response, error)
 
+		// ListCollaborators returns a list of repository collaborators.
+		ListCollaborators(ctx context.Context, repo string, opts ListOptions) ([]*User, *Response, error)
+
+		// AddCollaborator adds a repository collaborator with the
+		// given permission (read, write or admin). If the permission
+		// is empty the server default is used.
+		AddCollaborator(ctx context.Context, repo, collaborator, permission string) (*Response, error)
+
+		// RemoveCollaborator removes a repository collaborator.
+		RemoveCollaborator(ctx context.Context, repo, collaborator string) (*Response, error)
+
 		ListTeams(context.Context, string) ([]*Team, *Response, error)
 
+		// ListDeployKeys returns a list of repository deploy keys.
+		ListDeployKeys(ctx context.Context, repo string, opts ListOptions) ([]*structs.DeployKey, *Response, error)
+
+		// FindDeployKey returns a repository deploy key by id.
+		FindDeployKey(ctx context.Context, repo string, id int64) (*structs.DeployKey, *Response, error)
+
+		// CreateDeployKey creates a repository deploy key. The key
+		// is parsed and validated before it is sent to the server,
+		// and ErrDuplicateKey is returned if a deploy key with the
+		// same fingerprint already exists.
+		CreateDeployKey(ctx context.Context, repo string, in *structs.CreateKeyOption) (*structs.DeployKey, *Response, error)
+
+		// DeleteDeployKey deletes a repository deploy key.
+		DeleteDeployKey(ctx context.Context, repo string, id int64) (*Response, error)
+
+		// ListGitHooks returns the server-side git hooks of a
+		// repository (pre-receive, update and post-receive).
+		ListGitHooks(ctx context.Context, repo string) (structs.GitHookList, *Response, error)
+
+		// FindGitHook returns a repository git hook by name.
+		FindGitHook(ctx context.Context, repo, name string) (*structs.GitHook, *Response, error)
+
+		// UpdateGitHook updates the script of a repository git hook.
+		UpdateGitHook(ctx context.Context, repo, name string, in *structs.EditGitHookOption) (*structs.GitHook, *Response, error)
+
+		// DeleteGitHook deletes the script of a repository git hook.
+		DeleteGitHook(ctx context.Context, repo, name string) (*Response, error)
+
+		// ListIssueTemplates returns the issue templates of a
+		// repository, including YAML issue forms.
+		ListIssueTemplates(ctx context.Context, repo string) ([]*IssueTemplate, *Response, error)
+
 		// deploy + build
 		FindRequires(ctx context.Context, repo string) (*structs.Requirement, *Response, error)
 		ListClusters(ctx context.Context, repo string, opt QueryOption) ([]string, *Response, error)
@@ -0,0 +1,51 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// TeamService provides access to organization team resources.
+type TeamService interface {
+	// List returns the organization team list.
+	List(ctx context.Context, org string, opts ListOptions) ([]*Team, *Response, error)
+
+	// Search returns the organization teams matching the
+	// query by name, and optionally by description.
+	Search(ctx context.Context, org, query string, description bool, opts ListOptions) ([]*Team, *Response, error)
+
+	// Find returns the team by id.
+	Find(ctx context.Context, id int64) (*Team, *Response, error)
+
+	// Create creates a new organization team.
+	Create(ctx context.Context, org string, in *structs.CreateTeamOption) (*Team, *Response, error)
+
+	// Update updates a team, including its unit permissions.
+	Update(ctx context.Context, id int64, in *structs.EditTeamOption) (*Team, *Response, error)
+
+	// Delete deletes a team.
+	Delete(ctx context.Context, id int64) (*Response, error)
+
+	// ListMembers returns the team member list.
+	ListMembers(ctx context.Context, id int64, opts ListOptions) ([]*User, *Response, error)
+
+	// AddMember adds a user to the team.
+	AddMember(ctx context.Context, id int64, username string) (*Response, error)
+
+	// RemoveMember removes a user from the team.
+	RemoveMember(ctx context.Context, id int64, username string) (*Response, error)
+
+	// ListRepos returns the team repository list.
+	ListRepos(ctx context.Context, id int64, opts ListOptions) ([]*Repository, *Response, error)
+
+	// AddRepo grants the team access to the repository.
+	AddRepo(ctx context.Context, id int64, repo string) (*Response, error)
+
+	// RemoveRepo revokes the team access to the repository.
+	RemoveRepo(ctx context.Context, id int64, repo string) (*Response, error)
+}
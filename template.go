@@ -0,0 +1,153 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrRequiredField is returned when rendering an issue
+	// template without a value for a required field.
+	ErrRequiredField = errors.New("missing required field")
+
+	// ErrInvalidField is returned when rendering an issue
+	// template with a value that is not one of the field
+	// options.
+	ErrInvalidField = errors.New("invalid field value")
+)
+
+// Issue form field types.
+const (
+	IssueFormMarkdown   = "markdown"
+	IssueFormTextarea   = "textarea"
+	IssueFormInput      = "input"
+	IssueFormDropdown   = "dropdown"
+	IssueFormCheckboxes = "checkboxes"
+)
+
+type (
+	// IssueTemplate represents a repository issue template.
+	// Markdown templates provide the issue body; YAML issue
+	// forms provide a list of fields instead.
+	IssueTemplate struct {
+		Name     string
+		About    string
+		Title    string
+		Labels   []string
+		Ref      string
+		FileName string
+		Body     string
+		Fields   []*IssueFormField
+	}
+
+	// IssueFormField represents a field of a YAML issue form.
+	IssueFormField struct {
+		Type        string
+		ID          string
+		Label       string
+		Description string
+		Value       string
+		Options     []*IssueFormOption
+		Required    bool
+	}
+
+	// IssueFormOption represents a dropdown or checkbox option
+	// of an issue form field.
+	IssueFormOption struct {
+		Label    string
+		Required bool
+	}
+)
+
+// IsForm returns true if the template is a YAML issue form.
+func (t *IssueTemplate) IsForm() bool {
+	return len(t.Fields) != 0
+}
+
+// RenderIssue renders the issue input from the template and
+// the field values, keyed by field id (or label when the
+// field has no id). The title value overrides the template
+// title. Markdown templates use the body value, if provided,
+// in place of the template body. Checkbox values list the
+// checked options separated by newlines. The template labels
+// are not part of the rendered input and must be applied by
+// the caller.
+func RenderIssue(t *IssueTemplate, values map[string]string) (*IssueInput, error) {
+	in := &IssueInput{
+		Title: t.Title,
+		Body:  t.Body,
+	}
+	if v := values["title"]; v != "" {
+		in.Title = v
+	}
+	if !t.IsForm() {
+		if v := values["body"]; v != "" {
+			in.Body = v
+		}
+		return in, nil
+	}
+
+	var b strings.Builder
+	for _, field := range t.Fields {
+		if field.Type == IssueFormMarkdown {
+			continue
+		}
+		key := field.ID
+		if key == "" {
+			key = field.Label
+		}
+		value := strings.TrimSpace(values[key])
+		if value == "" {
+			value = field.Value
+		}
+
+		switch field.Type {
+		case IssueFormCheckboxes:
+			checked := map[string]bool{}
+			for _, s := range strings.Split(value, "\n") {
+				checked[strings.TrimSpace(s)] = true
+			}
+			fmt.Fprintf(&b, "### %s\n\n", field.Label)
+			for _, option := range field.Options {
+				if option.Required && !checked[option.Label] {
+					return nil, fmt.Errorf("%w: %s", ErrRequiredField, key)
+				}
+				mark := " "
+				if checked[option.Label] {
+					mark = "x"
+				}
+				fmt.Fprintf(&b, "- [%s] %s\n", mark, option.Label)
+			}
+			b.WriteString("\n")
+			continue
+		case IssueFormDropdown:
+			if value != "" && !field.hasOption(value) {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidField, key)
+			}
+		}
+
+		if value == "" && field.Required {
+			return nil, fmt.Errorf("%w: %s", ErrRequiredField, key)
+		}
+		if value == "" {
+			value = "_No response_"
+		}
+		fmt.Fprintf(&b, "### %s\n\n%s\n\n", field.Label, value)
+	}
+	in.Body = strings.TrimSpace(b.String())
+	return in, nil
+}
+
+func (f *IssueFormField) hasOption(s string) bool {
+	for _, option := range f.Options {
+		if option.Label == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+var testIssueForm = &IssueTemplate{
+	Name:  "Bug Report",
+	Title: "[Bug]: ",
+	Fields: []*IssueFormField{
+		{Type: IssueFormMarkdown, Value: "Thanks for the report!"},
+		{Type: IssueFormTextarea, ID: "what-happened", Label: "What happened?", Required: true},
+		{Type: IssueFormInput, ID: "logs", Label: "Logs"},
+		{Type: IssueFormDropdown, ID: "version", Label: "Version", Options: []*IssueFormOption{{Label: "1.0"}, {Label: "1.1"}}},
+		{Type: IssueFormCheckboxes, ID: "terms", Label: "Terms", Options: []*IssueFormOption{{Label: "I agree", Required: true}, {Label: "Subscribe"}}},
+	},
+}
+
+func TestRenderIssue(t *testing.T) {
+	got, err := RenderIssue(testIssueForm, map[string]string{
+		"title":         "[Bug]: crash on start",
+		"what-happened": "It crashed.",
+		"version":       "1.1",
+		"terms":         "I agree",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[Bug]: crash on start"; got.Title != want {
+		t.Errorf("Want title %q, got %q", want, got.Title)
+	}
+	want := "### What happened?\n\nIt crashed.\n\n" +
+		"### Logs\n\n_No response_\n\n" +
+		"### Version\n\n1.1\n\n" +
+		"### Terms\n\n- [x] I agree\n- [ ] Subscribe"
+	if got.Body != want {
+		t.Errorf("Want body %q, got %q", want, got.Body)
+	}
+}
+
+func TestRenderIssue_Markdown(t *testing.T) {
+	tmpl := &IssueTemplate{Title: "[Feature]: ", Body: "## Describe the feature\n"}
+	got, err := RenderIssue(tmpl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != tmpl.Title || got.Body != tmpl.Body {
+		t.Errorf("Expect template title and body, got %q and %q", got.Title, got.Body)
+	}
+}
+
+func TestRenderIssue_Invalid(t *testing.T) {
+	tests := []struct {
+		values map[string]string
+		err    error
+	}{
+		{map[string]string{"terms": "I agree"}, ErrRequiredField},
+		{map[string]string{"what-happened": "It crashed."}, ErrRequiredField},
+		{map[string]string{"what-happened": "It crashed.", "terms": "I agree", "version": "2.0"}, ErrInvalidField},
+	}
+	for _, test := range tests {
+		if _, err := RenderIssue(testIssueForm, test.values); !errors.Is(err, test.err) {
+			t.Errorf("Want error %v, got %v", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+// TimelineEvent represents an event in the timeline of an
+// issue or pull request. Depending on the event kind, Old
+// and New hold the previous and updated values, for example
+// the old and new title, milestone, branch or label name.
+type TimelineEvent struct {
+	ID       int64
+	Kind     TimelineEventKind
+	Author   User
+	Body     string
+	Link     string
+	Old      string
+	New      string
+	Label    *structs.Label
+	Assignee *User
+	RefIssue *structs.Issue
+	RefSHA   string
+	ReviewID int64
+	Created  time.Time
+	Updated  time.Time
+}
+
+// TimelineEventKind identifies the kind of timeline event.
+type TimelineEventKind int
+
+// TimelineEventKind values.
+const (
+	TimelineUnknown TimelineEventKind = iota
+	TimelineComment
+	TimelineReopen
+	TimelineClose
+	TimelineIssueRef
+	TimelineCommitRef
+	TimelineCommentRef
+	TimelinePullRef
+	TimelineLabel
+	TimelineUnlabel
+	TimelineMilestone
+	TimelineAssign
+	TimelineUnassign
+	TimelineChangeTitle
+	TimelineDeleteBranch
+	TimelineStartTracking
+	TimelineStopTracking
+	TimelineAddTime
+	TimelineCancelTracking
+	TimelineDeleteTime
+	TimelineAddDeadline
+	TimelineModifyDeadline
+	TimelineRemoveDeadline
+	TimelineAddDependency
+	TimelineRemoveDependency
+	TimelineCode
+	TimelineReview
+	TimelineReviewRequest
+	TimelineDismissReview
+	TimelineLock
+	TimelineUnlock
+	TimelineChangeTargetBranch
+	TimelineMerge
+	TimelinePush
+	TimelineProject
+	TimelineProjectBoard
+	TimelineChangeIssueRef
+)
+
+// timelineEventKinds maps timeline event kinds to the
+// timeline comment type reported by the server. The
+// unlabel and unassign kinds share the type of their
+// counterpart and are distinguished by the event payload.
+var timelineEventKinds = map[TimelineEventKind]string{
+	TimelineComment:            "comment",
+	TimelineReopen:             "reopen",
+	TimelineClose:              "close",
+	TimelineIssueRef:           "issue_ref",
+	TimelineCommitRef:          "commit_ref",
+	TimelineCommentRef:         "comment_ref",
+	TimelinePullRef:            "pull_ref",
+	TimelineLabel:              "label",
+	TimelineUnlabel:            "label",
+	TimelineMilestone:          "milestone",
+	TimelineAssign:             "assignees",
+	TimelineUnassign:           "assignees",
+	TimelineChangeTitle:        "change_title",
+	TimelineDeleteBranch:       "delete_branch",
+	TimelineStartTracking:      "start_tracking",
+	TimelineStopTracking:       "stop_tracking",
+	TimelineAddTime:            "add_time_manual",
+	TimelineCancelTracking:     "cancel_tracking",
+	TimelineDeleteTime:         "delete_time_manual",
+	TimelineAddDeadline:        "added_deadline",
+	TimelineModifyDeadline:     "modified_deadline",
+	TimelineRemoveDeadline:     "removed_deadline",
+	TimelineAddDependency:      "add_dependency",
+	TimelineRemoveDependency:   "remove_dependency",
+	TimelineCode:               "code",
+	TimelineReview:             "review",
+	TimelineReviewRequest:      "review_request",
+	TimelineDismissReview:      "dismiss_review",
+	TimelineLock:               "lock",
+	TimelineUnlock:             "unlock",
+	TimelineChangeTargetBranch: "change_target_branch",
+	TimelineMerge:              "merge_pull",
+	TimelinePush:               "pull_push",
+	TimelineProject:            "project",
+	TimelineProjectBoard:       "project_board",
+	TimelineChangeIssueRef:     "change_issue_ref",
+}
+
+// ParseTimelineEventKind returns the timeline event kind
+// for the timeline comment type reported by the server.
+// Label and assignee types resolve to TimelineLabel and
+// TimelineAssign respectively.
+func ParseTimelineEventKind(s string) TimelineEventKind {
+	switch s {
+	case "label":
+		return TimelineLabel
+	case "assignees":
+		return TimelineAssign
+	}
+	for k, v := range timelineEventKinds {
+		if v == s {
+			return k
+		}
+	}
+	return TimelineUnknown
+}
+
+// String returns the string representation of the
+// TimelineEventKind.
+func (k TimelineEventKind) String() string {
+	switch k {
+	case TimelineUnlabel:
+		return "unlabel"
+	case TimelineUnassign:
+		return "unassign"
+	}
+	if s, ok := timelineEventKinds[k]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+// MarshalJSON returns the JSON-encoded TimelineEventKind.
+func (k TimelineEventKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// UnmarshalJSON unmarshals the JSON-encoded TimelineEventKind.
+func (k *TimelineEventKind) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "unlabel":
+		*k = TimelineUnlabel
+	case "unassign":
+		*k = TimelineUnassign
+	default:
+		*k = ParseTimelineEventKind(s)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type (
+	// TrackedTimeListOptions provides options for querying
+	// a list of tracked times.
+	TrackedTimeListOptions struct {
+		URL    string
+		Page   int
+		Size   int
+		User   string
+		Since  time.Time
+		Before time.Time
+	}
+
+	// TimeService provides access to issue time tracking and
+	// stopwatch resources.
+	TimeService interface {
+		// ListIssue returns the tracked times of an issue.
+		ListIssue(ctx context.Context, repo string, number int, opts TrackedTimeListOptions) (structs.TrackedTimeList, *Response, error)
+
+		// ListRepo returns the tracked times of a repository.
+		ListRepo(ctx context.Context, repo string, opts TrackedTimeListOptions) (structs.TrackedTimeList, *Response, error)
+
+		// ListUser returns the tracked times of the authenticated user.
+		ListUser(ctx context.Context, opts TrackedTimeListOptions) (structs.TrackedTimeList, *Response, error)
+
+		// Add adds tracked time to an issue.
+		Add(ctx context.Context, repo string, number int, in *structs.AddTimeOption) (*structs.TrackedTime, *Response, error)
+
+		// Delete deletes a tracked time of an issue.
+		Delete(ctx context.Context, repo string, number int, id int64) (*Response, error)
+
+		// Reset deletes all tracked times of an issue.
+		Reset(ctx context.Context, repo string, number int) (*Response, error)
+
+		// StartStopwatch starts the stopwatch on an issue.
+		StartStopwatch(ctx context.Context, repo string, number int) (*Response, error)
+
+		// StopStopwatch stops the stopwatch on an issue and adds
+		// the elapsed time as tracked time.
+		StopStopwatch(ctx context.Context, repo string, number int) (*Response, error)
+
+		// CancelStopwatch cancels the stopwatch on an issue
+		// without adding tracked time.
+		CancelStopwatch(ctx context.Context, repo string, number int) (*Response, error)
+
+		// ListStopwatches returns the running stopwatches of the
+		// authenticated user.
+		ListStopwatches(ctx context.Context, opts ListOptions) (structs.StopWatches, *Response, error)
+	}
+)
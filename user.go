@@ -8,19 +8,29 @@ package api
 import (
 	"context"
 	"time"
+
+	"github.com/gitbundle/api/pkg/structs"
 )
 
 type (
 	// User represents a user account.
 	User struct {
-		ID      string
-		Login   string
-		Name    string
-		Email   string
-		Avatar  string
-		IsAdmin bool
-		Created time.Time
-		Updated time.Time
+		ID          string
+		Login       string
+		Name        string
+		Email       string
+		Avatar      string
+		Location    string
+		Website     string
+		Description string
+		Language    string
+		Visibility  string
+		IsAdmin     bool
+		Restricted  bool
+		Active      bool
+		LastLogin   time.Time
+		Created     time.Time
+		Updated     time.Time
 	}
 
 	// Email represents a user email.
@@ -41,7 +51,96 @@ type (
 		// FindLogin returns the user account by username.
 		FindLogin(context.Context, string) (*User, *Response, error)
 
-		// ListEmail returns the user email list.
+		// ListEmail returns the authenticated user email list.
 		ListEmail(context.Context, ListOptions) ([]*Email, *Response, error)
+
+		// CreateEmail adds email addresses to the authenticated
+		// user and returns the added addresses.
+		CreateEmail(context.Context, *structs.CreateEmailOption) ([]*Email, *Response, error)
+
+		// DeleteEmail removes email addresses from the
+		// authenticated user.
+		DeleteEmail(context.Context, *structs.DeleteEmailOption) (*Response, error)
+
+		// FindSettings returns the authenticated user settings.
+		FindSettings(context.Context) (*structs.UserSettings, *Response, error)
+
+		// UpdateSettings updates the authenticated user settings.
+		// Only the options that are set are changed.
+		UpdateSettings(context.Context, *structs.UserSettingsOptions) (*structs.UserSettings, *Response, error)
+
+		// ListKeys returns the authenticated user ssh key list.
+		ListKeys(context.Context, ListOptions) ([]*structs.PublicKey, *Response, error)
+
+		// FindKey returns the authenticated user ssh key by id.
+		FindKey(context.Context, int64) (*structs.PublicKey, *Response, error)
+
+		// CreateKey creates an ssh key for the authenticated user.
+		// The key is parsed and validated before it is sent to the
+		// server, and ErrDuplicateKey is returned if the user has a
+		// key with the same fingerprint.
+		CreateKey(context.Context, *structs.CreateKeyOption) (*structs.PublicKey, *Response, error)
+
+		// DeleteKey deletes an ssh key of the authenticated user.
+		DeleteKey(context.Context, int64) (*Response, error)
+
+		// ListGPGKeys returns the authenticated user gpg key list.
+		ListGPGKeys(context.Context, ListOptions) ([]*structs.GPGKey, *Response, error)
+
+		// FindGPGKey returns the authenticated user gpg key by id.
+		FindGPGKey(context.Context, int64) (*structs.GPGKey, *Response, error)
+
+		// CreateGPGKey creates a gpg key for the authenticated user.
+		CreateGPGKey(context.Context, *structs.CreateGPGKeyOption) (*structs.GPGKey, *Response, error)
+
+		// DeleteGPGKey deletes a gpg key of the authenticated user.
+		DeleteGPGKey(context.Context, int64) (*Response, error)
+
+		// FindGPGKeyToken returns the token the authenticated
+		// user must sign to verify ownership of a gpg key.
+		FindGPGKeyToken(context.Context) (string, *Response, error)
+
+		// VerifyGPGKey verifies a gpg key using the signed token.
+		VerifyGPGKey(context.Context, *structs.VerifyGPGKeyOption) (*structs.GPGKey, *Response, error)
+
+		// ListTokens returns the access token list of the user.
+		// The server only accepts basic authentication for access
+		// token management; configure the client with
+		// transport.BasicAuth.
+		ListTokens(ctx context.Context, login string, opts ListOptions) ([]*structs.AccessToken, *Response, error)
+
+		// CreateToken creates an access token for the user with
+		// the requested scopes. The secret token value is only
+		// returned by this call and cannot be retrieved later.
+		// The server only accepts basic authentication.
+		CreateToken(ctx context.Context, login string, in *structs.CreateAccessTokenOption) (*structs.AccessToken, *Response, error)
+
+		// DeleteToken deletes an access token of the user. The
+		// server only accepts basic authentication.
+		DeleteToken(ctx context.Context, login string, id int64) (*Response, error)
+
+		// ListOAuth2Apps returns the oauth2 application list of
+		// the authenticated user.
+		ListOAuth2Apps(ctx context.Context, opts ListOptions) ([]*structs.OAuth2Application, *Response, error)
+
+		// FindOAuth2App returns an oauth2 application of the
+		// authenticated user by id. The client secret is never
+		// returned.
+		FindOAuth2App(ctx context.Context, id int64) (*structs.OAuth2Application, *Response, error)
+
+		// CreateOAuth2App creates an oauth2 application for the
+		// authenticated user. The client secret is only returned
+		// by this call and cannot be retrieved later.
+		CreateOAuth2App(ctx context.Context, in *structs.CreateOAuth2ApplicationOptions) (*structs.OAuth2Application, *Response, error)
+
+		// UpdateOAuth2App updates an oauth2 application of the
+		// authenticated user. Updating the application generates
+		// a new client secret, which is only returned by this
+		// call.
+		UpdateOAuth2App(ctx context.Context, id int64, in *structs.CreateOAuth2ApplicationOptions) (*structs.OAuth2Application, *Response, error)
+
+		// DeleteOAuth2App deletes an oauth2 application of the
+		// authenticated user.
+		DeleteOAuth2App(ctx context.Context, id int64) (*Response, error)
 	}
 )
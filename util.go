@@ -6,6 +6,7 @@
 package api
 
 import (
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,8 +19,8 @@ var re = regexp.MustCompile("\\d+")
 // regular expressions to test whether or not a string is
 // a sha1 or sha256 commit hash.
 var (
-	sha1   = regexp.MustCompile("^([a-f0-9]{40})$")
-	sha256 = regexp.MustCompile("^([a-f0-9]{64})$")
+	sha1Hash   = regexp.MustCompile("^([a-f0-9]{40})$")
+	sha256Hash = regexp.MustCompile("^([a-f0-9]{64})$")
 )
 
 // Split splits the full repository name into segments.
@@ -86,7 +87,25 @@ func IsPullRequest(ref string) bool {
 		strings.HasPrefix(ref, "refs/merge-requests/")
 }
 
+// MatchRef returns true if the reference path matches the
+// glob pattern, using the syntax of path.Match where * does
+// not match a slash. A pattern also matches the references
+// nested below a matching path, so refs/pull/* matches
+// refs/pull/1/head. A malformed pattern matches nothing.
+func MatchRef(pattern, ref string) bool {
+	for {
+		if ok, _ := path.Match(pattern, ref); ok {
+			return true
+		}
+		i := strings.LastIndexByte(ref, '/')
+		if i == -1 {
+			return false
+		}
+		ref = ref[:i]
+	}
+}
+
 // IsHash returns true if the string is a commit hash.
 func IsHash(s string) bool {
-	return sha1.MatchString(s) || sha256.MatchString(s)
+	return sha1Hash.MatchString(s) || sha256Hash.MatchString(s)
 }
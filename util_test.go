@@ -195,6 +195,30 @@ func TestExtractPullRequest(t *testing.T) {
 	}
 }
 
+func TestMatchRef(t *testing.T) {
+	tests := []struct {
+		pattern string
+		ref     string
+		match   bool
+	}{
+		{"refs/heads/release/*", "refs/heads/release/1.0", true},
+		{"refs/heads/release/*", "refs/heads/release-notes", false},
+		{"refs/heads/release/*", "refs/heads/release", false},
+		{"refs/pull/*", "refs/pull/1/head", true},
+		{"refs/pull/*/head", "refs/pull/1/head", true},
+		{"refs/pull/*/head", "refs/pull/1/merge", false},
+		{"refs/tags/v1.?.0", "refs/tags/v1.2.0", true},
+		{"refs/tags/v[2-9]*", "refs/tags/v1.2.0", false},
+		{"refs/notes", "refs/notes/commits", true},
+		{"refs/heads/[", "refs/heads/main", false},
+	}
+	for _, test := range tests {
+		if got, want := MatchRef(test.pattern, test.ref), test.match; got != want {
+			t.Errorf("Got MatchRef(%q, %q) %v, want %v", test.pattern, test.ref, got, want)
+		}
+	}
+}
+
 func TestIsHash(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,52 @@
+// Copyright 2023 GitBundle Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+
+	"github.com/gitbundle/api/pkg/structs"
+)
+
+type (
+	// WikiPage represents a repository wiki page with its
+	// decoded content.
+	WikiPage struct {
+		structs.WikiPageMetaData
+		Content     []byte
+		CommitCount int64
+		Sidebar     string
+		Footer      string
+	}
+
+	// WikiPageInput provides the input fields required for
+	// creating or updating a wiki page.
+	WikiPageInput struct {
+		Title   string
+		Content []byte
+		Message string
+	}
+
+	// WikiService provides access to repository wiki resources.
+	WikiService interface {
+		// Find returns the wiki page by name.
+		Find(ctx context.Context, repo, page string) (*WikiPage, *Response, error)
+
+		// List returns the repository wiki page list.
+		List(ctx context.Context, repo string, opts ListOptions) ([]*structs.WikiPageMetaData, *Response, error)
+
+		// ListRevisions returns the revision history of a wiki page.
+		ListRevisions(ctx context.Context, repo, page string, opts ListOptions) ([]*structs.WikiCommit, *Response, error)
+
+		// Create creates a new wiki page.
+		Create(ctx context.Context, repo string, input *WikiPageInput) (*WikiPage, *Response, error)
+
+		// Update updates a wiki page.
+		Update(ctx context.Context, repo, page string, input *WikiPageInput) (*WikiPage, *Response, error)
+
+		// Delete deletes a wiki page.
+		Delete(ctx context.Context, repo, page string) (*Response, error)
+	}
+)